@@ -0,0 +1,113 @@
+package builder
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrBatchTooLarge = errors.New("a single row exceeds the batch limits")
+)
+
+// BatchOptions are the limits of InsertBatches on top of the limits of the SQL flavour, zero values mean no limit
+type BatchOptions struct {
+	// MaxParams caps the binding parameters of a statement, the lower of it and the dialect limit applies
+	MaxParams int
+	// MaxRows caps the rows of a statement, the lower of it and the dialect limit applies
+	MaxRows int
+	// MaxSQLSize caps the length of the SQL of a statement in bytes
+	MaxSQLSize int
+}
+
+// Batch is an INSERT statement generated by InsertBatches with its binding params
+type Batch struct {
+	SQL    string
+	Params []interface{}
+}
+
+// InsertBatches splits the rows into as many multi-row INSERT statements as needed to stay under the binding parameter
+// and row limits of the SQL flavour and the limits of the options. The statements are generated by a separate builder,
+// so the statement held by this one is kept
+func (b *Build) InsertBatches(tableName string, fields []string, rows [][]interface{}, opts BatchOptions) ([]Batch, error) {
+	if len(rows) == 0 {
+		return nil, nil
+	}
+
+	for _, row := range rows {
+		if len(row) != len(fields) {
+			return nil, errFieldCountMismatch
+		}
+	}
+
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("at least one field need to be inserted")
+	}
+
+	rowsPerBatch := len(rows)
+	if maxParams := minLimit(b.dialect.MaxParams(), opts.MaxParams); maxParams > 0 {
+		rowsPerBatch = maxParams / len(fields)
+	}
+
+	if maxRows := minLimit(b.dialect.MaxInsertRows(), opts.MaxRows); maxRows > 0 && maxRows < rowsPerBatch {
+		rowsPerBatch = maxRows
+	}
+
+	if rowsPerBatch == 0 {
+		return nil, fmt.Errorf("%w: %d fields", ErrBatchTooLarge, len(fields))
+	}
+
+	batch := &Build{dialect: b.dialect, strict: b.strict}
+	var batches []Batch
+	for start := 0; start < len(rows); start += rowsPerBatch {
+		end := start + rowsPerBatch
+		if end > len(rows) {
+			end = len(rows)
+		}
+
+		chunk, err := batch.insertBatch(tableName, fields, rows[start:end], opts.MaxSQLSize)
+		if err != nil {
+			return nil, err
+		}
+		batches = append(batches, chunk...)
+	}
+
+	return batches, nil
+}
+
+// insertBatch renders the rows as one statement, halving them while the SQL is longer than maxSQLSize
+func (b *Build) insertBatch(tableName string, fields []string, rows [][]interface{}, maxSQLSize int) ([]Batch, error) {
+	sql, err := b.Insert(tableName).Fields(fields...).Rows(rows...).AsSQL()
+	if err != nil {
+		return nil, err
+	}
+
+	if maxSQLSize == 0 || len(sql) <= maxSQLSize {
+		return []Batch{{SQL: sql, Params: b.GetParams()}}, nil
+	}
+
+	if len(rows) == 1 {
+		return nil, fmt.Errorf("%w: %d bytes of SQL", ErrBatchTooLarge, len(sql))
+	}
+
+	half := len(rows) / 2
+	first, err := b.insertBatch(tableName, fields, rows[:half], maxSQLSize)
+	if err != nil {
+		return nil, err
+	}
+
+	second, err := b.insertBatch(tableName, fields, rows[half:], maxSQLSize)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(first, second...), nil
+}
+
+// minLimit returns the lower of two limits, where 0 means no limit
+func minLimit(a, b int) int {
+	if a == 0 || (b > 0 && b < a) {
+		return b
+	}
+
+	return a
+}
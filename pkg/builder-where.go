@@ -1,6 +1,7 @@
 package builder
 
 import (
+	"fmt"
 	"strings"
 )
 
@@ -18,6 +19,30 @@ func (b *Build) Where(field, relation string, value interface{}) Builder {
 	return b
 }
 
+// WhereOp creates SQL WHERE block with a typed operator, like WhereOp("name", Regexp, "^J")
+func (b *Build) WhereOp(field string, op Op, value interface{}) Builder {
+	return b.Where(field, string(op), value)
+}
+
+// OrWhereOp creates SQL OrWhere block with a typed operator
+func (b *Build) OrWhereOp(field string, op Op, value interface{}) Builder {
+	return b.OrWhere(field, string(op), value)
+}
+
+// WhereColumn creates SQL WHERE block comparing two columns, like `left` = `right`, both sides are quoted and nothing is bound
+func (b *Build) WhereColumn(left, relation, right string) Builder {
+	b.where.AppendItem(NewWhereColumn(typeColumn, left, relation, right))
+
+	return b
+}
+
+// OrWhereColumn creates SQL OrWhere block comparing two columns
+func (b *Build) OrWhereColumn(left, relation, right string) Builder {
+	b.where.AppendItem(NewWhereColumn(typeOrColumn, left, relation, right))
+
+	return b
+}
+
 // RawWhere creates SQL WHERE block
 func (b *Build) RawWhere(field, relation string, value interface{}) Builder {
 	b.where.AppendItem(
@@ -142,36 +167,142 @@ func (b *Build) OrNotIn(fieldName string, pars ...interface{}) Builder {
 	return b
 }
 
+// InSub creates SQL IN (SELECT ...) with a subquery
+func (b *Build) InSub(fieldName string, sub Builder) Builder {
+	b.where.AppendItem(NewInSub(fieldName, sub))
+
+	return b
+}
+
+// NotInSub creates SQL NOT IN (SELECT ...) with a subquery
+func (b *Build) NotInSub(fieldName string, sub Builder) Builder {
+	b.where.AppendItem(NewNotInSub(fieldName, sub))
+
+	return b
+}
+
+// Exists creates SQL EXISTS (SELECT ...) with a subquery
+func (b *Build) Exists(sub Builder) Builder {
+	b.where.AppendItem(NewExists(sub))
+
+	return b
+}
+
+// NotExists creates SQL NOT EXISTS (SELECT ...) with a subquery
+func (b *Build) NotExists(sub Builder) Builder {
+	b.where.AppendItem(NewNotExists(sub))
+
+	return b
+}
+
+// WhereSub creates SQL WHERE block comparing a field to a subquery, like `field` = (SELECT ...)
+func (b *Build) WhereSub(field, relation string, sub Builder) Builder {
+	b.where.AppendItem(NewWhereSub(field, relation, sub))
+
+	return b
+}
+
+// Like creates SQL LIKE condition, the pattern is bound as it is
+func (b *Build) Like(field string, pattern interface{}) Builder {
+	b.where.AppendItem(NewLike(typeLike, field, pattern, false))
+
+	return b
+}
+
+// NotLike creates SQL NOT LIKE condition
+func (b *Build) NotLike(field string, pattern interface{}) Builder {
+	b.where.AppendItem(NewLike(typeNotLike, field, pattern, false))
+
+	return b
+}
+
+// OrLike creates SQL LIKE condition preceded by OR operator
+func (b *Build) OrLike(field string, pattern interface{}) Builder {
+	b.where.AppendItem(NewLike(typeOrLike, field, pattern, false))
+
+	return b
+}
+
+// ILike creates case insensitive SQL ILIKE condition, emulated as LOWER(`field`) LIKE LOWER(?) where ILIKE is not supported
+func (b *Build) ILike(field string, pattern interface{}) Builder {
+	b.where.AppendItem(NewLike(typeILike, field, pattern, false))
+
+	return b
+}
+
+// Contains creates SQL LIKE condition matching the value anywhere in the field, the wildcards in the value are escaped
+func (b *Build) Contains(field, value string) Builder {
+	b.where.AppendItem(NewLike(typeLike, field, likePattern{"%", value, "%"}, true))
+
+	return b
+}
+
+// StartsWith creates SQL LIKE condition matching the value at the beginning of the field, the wildcards in the value are escaped
+func (b *Build) StartsWith(field, value string) Builder {
+	b.where.AppendItem(NewLike(typeLike, field, likePattern{"", value, "%"}, true))
+
+	return b
+}
+
+// EndsWith creates SQL LIKE condition matching the value at the end of the field, the wildcards in the value are escaped
+func (b *Build) EndsWith(field, value string) Builder {
+	b.where.AppendItem(NewLike(typeLike, field, likePattern{"%", value, ""}, true))
+
+	return b
+}
+
 func (b *Build) generateWhere(w Where) string {
 	strBuilder := &strings.Builder{}
 	isFirst := true
 	for _, item := range w.GetItems() {
+		if err := item.GetError(); err != nil {
+			b.setError(err)
+		}
 		operator := b.getWhereOperator(item.GetOperator())
 
+		if isFirst {
+			isFirst = false
+		} else {
+			builderConcat(
+				strBuilder,
+				" ", operator, " ",
+			)
+		}
+
 		if item.GetItems() != nil {
 			builderConcat(
 				strBuilder,
-				" ", operator, " (", b.generateWhere(item), ")",
+				"(", b.generateWhere(item), ")",
 			)
 
 		} else {
-			if isFirst {
-				isFirst = false
-			} else {
-				builderConcat(
-					strBuilder,
-					" ", operator, " ",
-				)
+
+			field := ""
+			switch {
+			case item.GetOperator() == typeExists || item.GetOperator() == typeNotExists:
+				// no field, EXISTS only has a subquery
+			case item.GetIsRaw():
+				field = item.GetField()
+			default:
+				field = b.quote(b.qualify(item.GetField()))
 			}
 
-			if item.GetIsRaw() {
-				builderConcat(strBuilder, item.GetField())
-			} else {
-				builderConcat(
-					strBuilder,
-					b.fieldQuote, item.GetField(), b.fieldQuote,
-				)
+			switch item.GetOperator() {
+			case typeAnd, typeOr:
+				strBuilder.WriteString(b.generateRelation(item, field, b.getBindingParameter()))
+				continue
+			case typeWhereSub:
+				strBuilder.WriteString(b.generateRelation(item, field, "("+b.generateSubSQL(item.GetSub())+")"))
+				continue
+			case typeColumn, typeOrColumn:
+				strBuilder.WriteString(b.generateRelation(item, field, b.quote(b.qualify(item.GetColumn()))))
+				continue
+			case typeILike:
+				if !b.dialect.Supports(FeatureILike) {
+					field = "LOWER(" + field + ")"
+				}
 			}
+			strBuilder.WriteString(field)
 
 			itemValueCount := len(item.GetInValues())
 			switch item.GetOperator() {
@@ -203,11 +334,24 @@ func (b *Build) generateWhere(w Where) string {
 					strBuilder.WriteString(b.getBindingParameter())
 				}
 				strBuilder.WriteString(")")
-			default:
-				builderConcat(
-					strBuilder,
-					item.GetRelation(), b.getBindingParameter(),
-				)
+			case typeInSub:
+				builderConcat(strBuilder, " IN (", b.generateSubSQL(item.GetSub()), ")")
+			case typeNotInSub:
+				builderConcat(strBuilder, " NOT IN (", b.generateSubSQL(item.GetSub()), ")")
+			case typeExists:
+				builderConcat(strBuilder, "EXISTS (", b.generateSubSQL(item.GetSub()), ")")
+			case typeNotExists:
+				builderConcat(strBuilder, "NOT EXISTS (", b.generateSubSQL(item.GetSub()), ")")
+			case typeLike, typeOrLike:
+				builderConcat(strBuilder, " LIKE ", b.getBindingParameter(), b.getLikeEscape(item))
+			case typeNotLike:
+				builderConcat(strBuilder, " NOT LIKE ", b.getBindingParameter(), b.getLikeEscape(item))
+			case typeILike:
+				if b.dialect.Supports(FeatureILike) {
+					builderConcat(strBuilder, " ILIKE ", b.getBindingParameter(), b.getLikeEscape(item))
+				} else {
+					builderConcat(strBuilder, " LIKE LOWER(", b.getBindingParameter(), ")", b.getLikeEscape(item))
+				}
 			}
 
 		}
@@ -215,11 +359,30 @@ func (b *Build) generateWhere(w Where) string {
 	return strBuilder.String()
 }
 
+func (b *Build) getLikeEscape(item Where) string {
+	if !item.GetIsEscaped() {
+		return ""
+	}
+
+	return " ESCAPE " + b.dialect.LikeEscape()
+}
+
+// generateRelation renders the field and the already rendered value with the relation of the item in the dialect
+func (b *Build) generateRelation(item Where, field, value string) string {
+	format, ok := b.dialect.Operator(Op(item.GetRelation()))
+	if !ok {
+		b.setError(fmt.Errorf("%w: operator %s", ErrNotSupported, item.GetRelation()))
+		return ""
+	}
+
+	return fmt.Sprintf(format, field, value)
+}
+
 func (b *Build) getWhereOperator(t int) string {
 	switch t {
 	case typeAnd, typeBetween:
 		return operatorAnd
-	case typeOr, typeOrBetween, typeOrIsNotNull, typeOrIsNull, typeOrIn, typeOrNotIn:
+	case typeOr, typeOrBetween, typeOrIsNotNull, typeOrIsNull, typeOrIn, typeOrNotIn, typeOrLike, typeOrColumn:
 		return operatorOr
 	default:
 		return operatorAnd
@@ -234,13 +397,21 @@ func (b *Build) getWhereParams(w Where) []interface{} {
 			pars = append(pars, b.getWhereParams(item)...)
 		} else {
 			switch item.GetOperator() {
-			case typeIsNull, typeIsNotNull, typeOrIsNull, typeOrIsNotNull:
+			case typeIsNull, typeIsNotNull, typeOrIsNull, typeOrIsNotNull, typeColumn, typeOrColumn:
 				// do nothing, no parameter
 			case typeIn, typeNotIn, typeOrIn, typeOrNotIn:
 				pars = append(pars, item.GetInValues()...)
 			case typeBetween, typeOrBetween:
 				pars = append(pars, item.GetValue(), item.GetValue2())
+			case typeInSub, typeNotInSub, typeExists, typeNotExists, typeWhereSub:
+				if item.GetSub() != nil {
+					pars = append(pars, b.getSubParams(item.GetSub())...)
+				}
 			default:
+				if pattern, ok := item.GetValue().(likePattern); ok {
+					pars = append(pars, pattern.prefix+b.dialect.EscapeLike(pattern.value)+pattern.suffix)
+					continue
+				}
 				pars = append(pars, item.GetValue())
 			}
 		}
@@ -4,17 +4,22 @@ package builder
 import (
 	"errors"
 	"fmt"
-	"strconv"
+	"strings"
 )
 
 const (
-	typeSelect    = 1
-	typeInsert    = 2
-	typeDelete    = 3
-	typeUpdate    = 4
-	joinTypeInner = "JOIN"
-	joinTypeLeft  = "LEFT JOIN"
-	joinTypeRight = "RIGHT JOIN"
+	typeSelect      = 1
+	typeInsert      = 2
+	typeDelete      = 3
+	typeUpdate      = 4
+	joinTypeInner   = "JOIN"
+	joinTypeLeft    = "LEFT JOIN"
+	joinTypeRight   = "RIGHT JOIN"
+	joinTypeFull    = "FULL JOIN"
+	joinTypeCross   = "CROSS JOIN"
+	joinTypeNatural = "NATURAL JOIN"
+	joinTypeLateral = "CROSS JOIN LATERAL"
+	joinTypeApply   = "CROSS APPLY"
 
 	// FlavourSqLite sets SQLite quite and parameter binding type
 	FlavourSqLite = 1
@@ -24,16 +29,32 @@ const (
 	FlavourPgSQL = 3
 	// FlavourFirebirdSQL sets FirebirdSQL quite and parameter binding type
 	FlavourFirebirdSQL = 4
+	// FlavourMSSQL sets Microsoft SQL Server quote, parameter binding type and pagination
+	FlavourMSSQL = 5
+	// FlavourOracle sets Oracle quote, parameter binding type and pagination
+	FlavourOracle = 6
+	// FlavourMariaDB sets MySql quote and parameter binding type with MariaDB features, like RETURNING
+	FlavourMariaDB = 7
 )
 
 var (
 	ErrInvalidSQLFlavour = errors.New("invalid SQL flavour")
+	ErrInvalidDialect    = errors.New("invalid SQL dialect")
+	ErrInvalidIdentifier = errors.New("invalid SQL identifier")
+	ErrInvalidSubquery   = errors.New("invalid subquery, it must be created by New")
+	ErrNotSupported      = errors.New("not supported by the SQL flavour")
 )
 
 // Builder is the base SQL builder interface
 type Builder interface {
 	SetSQLFlavour(int) error
+	SetDialect(Dialect) error
+	SetStrict(bool) Builder
 	Where(field, relation string, value interface{}) Builder
+	WhereOp(field string, op Op, value interface{}) Builder
+	OrWhereOp(field string, op Op, value interface{}) Builder
+	WhereColumn(left, relation, right string) Builder
+	OrWhereColumn(left, relation, right string) Builder
 	RawWhere(field, relation string, value interface{}) Builder
 	OrWhere(field, relation string, value interface{}) Builder
 	RawOrWhere(field, relation string, value interface{}) Builder
@@ -49,31 +70,81 @@ type Builder interface {
 	OrIn(string, ...interface{}) Builder
 	OrNotIn(string, ...interface{}) Builder
 	OrWhereGroup(fn WhereGroupFunc) Builder
+	InSub(string, Builder) Builder
+	NotInSub(string, Builder) Builder
+	Exists(Builder) Builder
+	NotExists(Builder) Builder
+	WhereSub(field, relation string, sub Builder) Builder
+	Like(field string, pattern interface{}) Builder
+	NotLike(field string, pattern interface{}) Builder
+	OrLike(field string, pattern interface{}) Builder
+	ILike(field string, pattern interface{}) Builder
+	Contains(field, value string) Builder
+	StartsWith(field, value string) Builder
+	EndsWith(field, value string) Builder
 	AsSQL() (string, error)
 	GetParams() []interface{}
 	Delete(tableName string) Builder
 	Insert(tableName string) Builder
 	Fields(fields ...string) Builder
 	RawFields(fields ...string) Builder
+	Columns(columns ...interface{}) Builder
 	Values(values ...interface{}) Builder
+	Rows(rows ...[]interface{}) Builder
+	AddRow(values ...interface{}) Builder
+	FromSelect(sub Builder) Builder
+	OnConflict(columns ...string) Builder
+	DoUpdate(fields ...string) Builder
+	DoNothing() Builder
+	Returning(columns ...string) Builder
+	InsertBatches(tableName string, fields []string, rows [][]interface{}, opts BatchOptions) ([]Batch, error)
 	Join(tableName, leftCond, rightCond string, fn WhereGroupFunc) Builder
 	LeftJoin(tableName, leftCond, rightCond string, fn WhereGroupFunc) Builder
 	RightJoin(tableName, leftCond, rightCond string, fn WhereGroupFunc) Builder
+	JoinAs(tableName, alias, leftCond, rightCond string, fn WhereGroupFunc) Builder
+	LeftJoinAs(tableName, alias, leftCond, rightCond string, fn WhereGroupFunc) Builder
+	RightJoinAs(tableName, alias, leftCond, rightCond string, fn WhereGroupFunc) Builder
+	JoinOn(tableName string, fn OnFunc) Builder
+	LeftJoinOn(tableName string, fn OnFunc) Builder
+	RightJoinOn(tableName string, fn OnFunc) Builder
+	JoinUsing(tableName string, columns ...string) Builder
+	FullJoin(tableName, leftCond, rightCond string, fn WhereGroupFunc) Builder
+	CrossJoin(tableName string) Builder
+	NaturalJoin(tableName string) Builder
+	LateralJoin(sub Builder, alias string) Builder
+	JoinSub(sub Builder, alias, leftCond, rightCond string, fn WhereGroupFunc) Builder
 	Select(tableName string) Builder
+	SelectAs(tableName, alias string) Builder
+	SelectFrom(sub Builder, alias string) Builder
+	FieldAs(field, alias string) Builder
 	GroupBy(fields ...string) Builder
+	Having(field, relation string, value interface{}) Builder
+	OrHaving(field, relation string, value interface{}) Builder
+	HavingRaw(field, relation string, value interface{}) Builder
+	HavingGroup(fn WhereGroupFunc) Builder
 	OrderBy(fields ...string) Builder
+	OrderByAsc(fields ...string) Builder
+	OrderByDesc(fields ...string) Builder
+	OrderByExpr(field, direction string, nulls int) Builder
 	Limit(l int) Builder
 	Offset(o int) Builder
+	Union(others ...Builder) Builder
+	UnionAll(others ...Builder) Builder
+	Intersect(others ...Builder) Builder
+	Except(others ...Builder) Builder
 	Update(tableName string) Builder
+	With(name string, q Builder) Builder
+	WithRecursive(name string, columns []string, anchor, recursive Builder) Builder
+	WithMaterialized(name string, q Builder) Builder
 }
 
 // New creates new SQL builder
 func New() Builder {
 	return &Build{
-		fieldQuote:   "`",
-		bindingStyle: "?",
-		where:        NewBlankWhere(),
-		joins:        make([]*Join, 0),
+		dialect: MySQLDialect{},
+		where:   &Wh{},
+		having:  &Wh{},
+		joins:   make([]*Join, 0),
 	}
 }
 
@@ -81,32 +152,48 @@ func New() Builder {
 type Build struct {
 	sQLType        int
 	tableName      string
-	fieldQuote     string
-	bindingStyle   string
-	fields         []string
-	fieldsAreRaw   bool
+	tableAlias     string
+	fromSub        Builder
+	dialect        Dialect
+	fields         []*column
 	values         []interface{}
+	rows           [][]interface{}
+	insertFrom     Builder
+	upsert         *upsert
+	returning      []string
 	where          Where
+	having         Where
 	groupBy        []string
-	orderBy        []string
+	orderBy        []*orderItem
 	limit          int
 	offset         int
 	joins          []*Join
+	compounds      []*compound
 	parameterCount int
+	strict         bool
+	ctes           []*cte
+	ctesPending    bool
+	fieldTable     string
+	err            error
 }
 
 // SetSQLFlavour can set your preferred SQL engine, as they have different quotation mark and parameter binding
 func (b *Build) SetSQLFlavour(sQLFlavour int) error {
 	switch sQLFlavour {
-	case FlavourSqLite, FlavourFirebirdSQL:
-		b.fieldQuote = "\""
-		b.bindingStyle = "?"
+	case FlavourSqLite:
+		b.dialect = SQLiteDialect{}
 	case FlavourMySQL:
-		b.fieldQuote = "`"
-		b.bindingStyle = "?"
+		b.dialect = MySQLDialect{}
 	case FlavourPgSQL:
-		b.fieldQuote = "\""
-		b.bindingStyle = "$"
+		b.dialect = PgSQLDialect{}
+	case FlavourFirebirdSQL:
+		b.dialect = FirebirdDialect{}
+	case FlavourMSSQL:
+		b.dialect = MSSQLDialect{}
+	case FlavourOracle:
+		b.dialect = OracleDialect{}
+	case FlavourMariaDB:
+		b.dialect = MariaDBDialect{}
 	default:
 		return ErrInvalidSQLFlavour
 	}
@@ -114,57 +201,208 @@ func (b *Build) SetSQLFlavour(sQLFlavour int) error {
 	return nil
 }
 
+// SetDialect sets a custom Dialect, for SQL engines or engine versions not covered by SetSQLFlavour
+func (b *Build) SetDialect(dialect Dialect) error {
+	if dialect == nil {
+		return ErrInvalidDialect
+	}
+
+	b.dialect = dialect
+
+	return nil
+}
+
+// SetStrict turns on strict mode, where constructing an invalid WHERE condition panics instead of AsSQL returning an error.
+// Use it when conditions never come from user input, so programming errors surface early
+func (b *Build) SetStrict(strict bool) Builder {
+	b.strict = strict
+	for _, w := range []Where{b.where, b.having} {
+		if wh, ok := w.(*Wh); ok {
+			wh.strict = strict
+		}
+	}
+
+	return b
+}
+
+// newWhere creates a root Where tree in the strict mode of the builder
+func (b *Build) newWhere() Where {
+	return &Wh{strict: b.strict}
+}
+
 // AsSQL returns the SQL representation of the build SQL command
 func (b *Build) AsSQL() (string, error) {
+	b.err = nil
+	b.parameterCount = 0
+	b.ctesPending = false
+	sql, err := b.generateSQL()
+	if err != nil {
+		return "", err
+	}
+
+	if b.err != nil {
+		return "", b.err
+	}
+
+	return sql, nil
+}
+
+func (b *Build) generateSQL() (string, error) {
+	if b.sQLType == 0 {
+		return "", fmt.Errorf("invalid SQL type")
+	}
+
+	withSQL := ""
+	switch {
+	case b.embedsWith():
+		// rendered before the SELECT by generateInsertSelectSQL
+	case b.leadsWith():
+		withSQL = b.generateWith()
+	case len(b.ctes) > 0:
+		b.setError(fmt.Errorf("%w: WITH in this statement", ErrNotSupported))
+	}
+
+	var sql string
+	var err error
 	switch b.sQLType {
 	case typeSelect:
-		return b.generateSelectSQL()
+		sql, err = b.generateSelectSQL()
 	case typeInsert:
-		return b.generateInsertSQL()
+		sql, err = b.generateInsertSQL()
 	case typeDelete:
-		return b.generateDeleteSQL()
+		sql, err = b.generateDeleteSQL()
 	case typeUpdate:
-		return b.generateUpdateSQL()
+		sql, err = b.generateUpdateSQL()
 	default:
 		return "", fmt.Errorf("invalid SQL type")
 	}
+
+	return withSQL + sql, err
 }
 
 func (b *Build) reset() {
+	if !b.ctesPending {
+		b.ctes = nil
+	}
+	b.ctesPending = false
 	b.parameterCount = 0
-	b.fieldsAreRaw = false
 	b.tableName = ""
-	b.fields = make([]string, 0)
+	b.tableAlias = ""
+	b.fromSub = nil
+	b.fields = make([]*column, 0)
 	b.values = make([]interface{}, 0)
 	b.groupBy = make([]string, 0)
-	b.orderBy = make([]string, 0)
-	b.values = make([]interface{}, 0)
-	b.where = NewBlankWhere()
+	b.orderBy = make([]*orderItem, 0)
+	b.rows = nil
+	b.insertFrom = nil
+	b.upsert = nil
+	b.returning = nil
+	b.where = b.newWhere()
+	b.having = b.newWhere()
 	b.limit = 0
 	b.offset = 0
 	b.joins = make([]*Join, 0)
+	b.compounds = nil
 }
 
 // GetParams returns the binding params for the last generated SQL
 func (b *Build) GetParams() []interface{} {
 	switch b.sQLType {
 	case typeSelect:
-		return b.getSelectParams()
+		return append(b.getWithParams(), b.getSelectParams()...)
 	case typeInsert:
-		return b.values
+		return append(b.getWithParams(), b.getInsertParams()...)
 	case typeDelete:
-		return b.getWhereParams(b.where)
+		return append(b.getWithParams(), b.getWhereParams(b.where)...)
 	case typeUpdate:
-		return append(b.values, b.getWhereParams(b.where)...)
+		pars := append(b.getWithParams(), b.values...)
+		return append(pars, b.getWhereParams(b.where)...)
 	default:
 		return nil
 	}
 }
 
 func (b *Build) getBindingParameter() string {
-	if b.bindingStyle == "?" {
-		return b.bindingStyle
-	}
 	b.parameterCount++
-	return b.bindingStyle + strconv.Itoa(b.parameterCount)
+	return b.dialect.Placeholder(b.parameterCount)
+}
+
+// quote quotes a, possibly qualified, identifier like schema.table or table.field part by part, leaving * unquoted
+func (b *Build) quote(name string) string {
+	parts := strings.Split(name, ".")
+	for i, part := range parts {
+		if part == "*" {
+			continue
+		}
+
+		if err := b.dialect.ValidateIdentifier(part); err != nil {
+			b.setError(err)
+		}
+		parts[i] = b.dialect.QuoteIdentifier(part)
+	}
+
+	return strings.Join(parts, ".")
+}
+
+// qualify prefixes a field without table name with fieldTable, if it is set
+func (b *Build) qualify(name string) string {
+	if b.fieldTable == "" || strings.Contains(name, ".") {
+		return name
+	}
+
+	return b.fieldTable + "." + name
+}
+
+// generateSubSQL renders a nested builder with the dialect of this one, continuing its binding parameter numbering
+func (b *Build) generateSubSQL(sub Builder) string {
+	s, ok := sub.(*Build)
+	if !ok || s == nil {
+		b.setError(ErrInvalidSubquery)
+		return ""
+	}
+
+	dialect := s.dialect
+	s.dialect = b.dialect
+	s.parameterCount = b.parameterCount
+	s.err = nil
+
+	sql, err := s.generateSQL()
+	if err == nil {
+		err = s.err
+	}
+
+	b.parameterCount = s.parameterCount
+	s.dialect = dialect
+
+	if err != nil {
+		b.setError(err)
+	}
+
+	return sql
+}
+
+// getSubParams returns the params of a nested builder, collected with the dialect it is rendered with by generateSubSQL
+func (b *Build) getSubParams(sub Builder) []interface{} {
+	s, ok := sub.(*Build)
+	if !ok {
+		return sub.GetParams()
+	}
+
+	if s == nil {
+		return nil
+	}
+
+	dialect := s.dialect
+	s.dialect = b.dialect
+	pars := s.GetParams()
+	s.dialect = dialect
+
+	return pars
+}
+
+// setError keeps the first error occurred while generating the SQL, AsSQL returns it
+func (b *Build) setError(err error) {
+	if b.err == nil {
+		b.err = err
+	}
 }
@@ -57,7 +57,7 @@ func (t *TestSuite) TestComplexSelect() {
 		AsSQL()
 
 	t.Nil(err)
-	t.Equal(sql, "SELECT `field1`,`field2` FROM `table1` JOIN `table2` ON `table1.id`=`table2.table1_id`  AND `join1`=? AND `join3`=? LEFT JOIN `table1l` ON `table1l.id`=`table2l.table1_id`  AND `join1l`=? AND `join3l`=? RIGHT JOIN `table1r` ON `table1r.id`=`table2r.table1_id`  AND `join1r`=? AND `join3r`=? WHERE `f1`=? OR `f2`=? AND `f3`=? AND (`sf4`=? AND `sf5`=? AND `btw` BETWEEN ? AND ?  AND (`ssf6`=? OR `ssf7`=? OR `ssf8`<=? OR (`ssssf9`=?))) AND `SL1`>? OR `orb` BETWEEN ? AND ?  GROUP BY `f1`,`f2`,`f3` ORDER BY `f5`,`f99`,`f44` LIMIT 10 OFFSET 100")
+	t.Equal(sql, "SELECT `field1`,`field2` FROM `table1` JOIN `table2` ON `table1`.`id`=`table2`.`table1_id` AND `join1`=? AND `join3`=? LEFT JOIN `table1l` ON `table1l`.`id`=`table2l`.`table1_id` AND `join1l`=? AND `join3l`=? RIGHT JOIN `table1r` ON `table1r`.`id`=`table2r`.`table1_id` AND `join1r`=? AND `join3r`=? WHERE `f1`=? OR `f2`=? AND `f3`=? AND (`sf4`=? AND `sf5`=? AND `btw` BETWEEN ? AND ?  AND (`ssf6`=? OR `ssf7`=? OR `ssf8`<=? OR (`ssssf9`=?))) AND `SL1`>? OR `orb` BETWEEN ? AND ?  GROUP BY `f1`,`f2`,`f3` ORDER BY `f5`,`f99`,`f44` LIMIT 10 OFFSET 100")
 
 	whereParams := builder.GetParams()
 	t.Len(whereParams, 20)
@@ -375,3 +375,869 @@ func (t *TestSuite) TestRawWhere() {
 	whereParams := builder.GetParams()
 	t.Len(whereParams, 6)
 }
+
+func (t *TestSuite) TestQualifiedIdentifiers() {
+	builder := New()
+	sql, err := builder.
+		Select("schema1.table1").
+		Fields("table1.*", "table2.field2").
+		Join("table2", "table1.id", "table2.table1_id", func(w Where) {
+			w.Where("table2.active", "=", 1)
+		}).
+		Where("table2.field1", "=", 5).
+		AsSQL()
+
+	t.Nil(err)
+	t.Equal(sql, "SELECT `table1`.*,`table2`.`field2` FROM `schema1`.`table1` JOIN `table2` ON `table1`.`id`=`table2`.`table1_id` AND `table2`.`active`=? WHERE `table2`.`field1`=?")
+
+	sql, err = builder.
+		Update("schema1.table1").
+		Fields("field1").
+		Values(1).
+		Where("table1.id", "=", 5).
+		AsSQL()
+
+	t.Nil(err)
+	t.Equal(sql, "UPDATE `schema1`.`table1` SET `field1`=? WHERE `table1`.`id`=?")
+}
+
+func (t *TestSuite) TestAliases() {
+	builder := New()
+	sql, err := builder.
+		SelectAs("users", "u").
+		Fields("u.id").
+		FieldAs("u.name", "user_name").
+		FieldAs("o.total", "order_total").
+		LeftJoinAs("orders", "o", "o.user_id", "u.id", func(w Where) {
+			w.Where("o.status", "=", "paid")
+		}).
+		AsSQL()
+
+	t.Nil(err)
+	t.Equal(sql, "SELECT `u`.`id`,`u`.`name` AS `user_name`,`o`.`total` AS `order_total` FROM `users` AS `u` LEFT JOIN `orders` AS `o` ON `o`.`user_id`=`u`.`id` AND `o`.`status`=?")
+
+	whereParams := builder.GetParams()
+	t.Len(whereParams, 1)
+}
+
+func (t *TestSuite) TestColumns() {
+	builder := New()
+	sql, err := builder.
+		Select("table1").
+		Columns("item_id", Raw("count(*)").As("cnt")).
+		Where("field1", "=", 5).
+		GroupBy("item_id").
+		AsSQL()
+
+	t.Nil(err)
+	t.Equal(sql, "SELECT `item_id`,count(*) AS `cnt` FROM `table1` WHERE `field1`=? GROUP BY `item_id`")
+
+	whereParams := builder.GetParams()
+	t.Len(whereParams, 1)
+}
+
+func (t *TestSuite) TestColumnsWithParams() {
+	builder := New()
+	builder.SetSQLFlavour(FlavourPgSQL)
+	sql, err := builder.
+		Select("table1").
+		Columns("item_id", Raw("coalesce(price, ?) * ?", 0, 2).As("price")).
+		Where("field1", "=", 5).
+		AsSQL()
+
+	t.Nil(err)
+	t.Equal(sql, "SELECT \"item_id\",coalesce(price, $1) * $2 AS \"price\" FROM \"table1\" WHERE \"field1\"=$3")
+
+	whereParams := builder.GetParams()
+	t.Equal(whereParams, []interface{}{0, 2, 5})
+}
+
+func (t *TestSuite) TestRawParamsOutsideSelect() {
+	builder := New()
+	t.Nil(builder.SetSQLFlavour(FlavourPgSQL))
+
+	_, err := builder.
+		Update("t").
+		Columns(Raw("a = a + ?", 5)).
+		Values(1).
+		Where("id", "=", 2).
+		AsSQL()
+
+	t.ErrorIs(err, ErrInvalidColumn)
+
+	_, err = builder.
+		Insert("t").
+		Columns(Raw("f(?)", 5)).
+		Values(1).
+		AsSQL()
+
+	t.ErrorIs(err, ErrInvalidColumn)
+}
+
+func (t *TestSuite) TestRawParamCountMismatch() {
+	builder := New()
+	_, err := builder.
+		Select("t").
+		Columns(Raw("f(?, ?)", 1)).
+		AsSQL()
+
+	t.ErrorIs(err, ErrInvalidColumn)
+
+	sql, err := builder.
+		Select("t").
+		Columns(Raw("f(?, ?)", 1, 2)).
+		AsSQL()
+
+	t.Nil(err)
+	t.Equal(sql, "SELECT f(?, ?) FROM `t`")
+}
+
+func (t *TestSuite) TestInvalidColumn() {
+	builder := New()
+	_, err := builder.
+		Select("table1").
+		Columns("item_id", 5).
+		AsSQL()
+
+	t.ErrorIs(err, ErrInvalidColumn)
+}
+
+func (t *TestSuite) TestOrderByDirection() {
+	builder := New()
+	sql, err := builder.
+		Select("table1").
+		OrderByDesc("created_at").
+		OrderByAsc("name", "id").
+		OrderBy("f1").
+		AsSQL()
+
+	t.Nil(err)
+	t.Equal(sql, "SELECT * FROM `table1` ORDER BY `created_at` DESC,`name` ASC,`id` ASC,`f1`")
+
+	_, err = builder.
+		Select("table1").
+		OrderByExpr("created_at", "sideways", NullsDefault).
+		AsSQL()
+
+	t.ErrorIs(err, ErrInvalidOrder)
+}
+
+func (t *TestSuite) TestOrderByNulls() {
+	builder := New()
+	sql, err := builder.
+		Select("table1").
+		OrderByExpr("deleted_at", OrderDesc, NullsFirst).
+		OrderByExpr("name", "", NullsLast).
+		AsSQL()
+
+	t.Nil(err)
+	t.Equal(sql, "SELECT * FROM `table1` ORDER BY CASE WHEN `deleted_at` IS NULL THEN 0 ELSE 1 END,`deleted_at` DESC,CASE WHEN `name` IS NULL THEN 1 ELSE 0 END,`name`")
+
+	builder.SetSQLFlavour(FlavourPgSQL)
+	sql, err = builder.
+		Select("table1").
+		OrderByExpr("deleted_at", OrderDesc, NullsFirst).
+		OrderByExpr("name", "", NullsLast).
+		AsSQL()
+
+	t.Nil(err)
+	t.Equal(sql, "SELECT * FROM \"table1\" ORDER BY \"deleted_at\" DESC NULLS FIRST,\"name\" NULLS LAST")
+}
+
+func (t *TestSuite) TestHaving() {
+	builder := New()
+	builder.SetSQLFlavour(FlavourPgSQL)
+	sql, err := builder.
+		Select("orders").
+		Columns("customer_id", Raw("sum(total)").As("total")).
+		Where("status", "=", "paid").
+		GroupBy("customer_id").
+		HavingRaw("sum(total)", ">", 100).
+		OrHaving("customer_id", "=", 5).
+		HavingGroup(func(w Where) {
+			w.In("customer_id", 1, 2)
+		}).
+		OrderByDesc("customer_id").
+		Limit(10).
+		AsSQL()
+
+	t.Nil(err)
+	t.Equal(sql, "SELECT \"customer_id\",sum(total) AS \"total\" FROM \"orders\" WHERE \"status\"=$1 GROUP BY \"customer_id\" HAVING sum(total)>$2 OR \"customer_id\"=$3 AND (\"customer_id\" IN ($4,$5)) ORDER BY \"customer_id\" DESC LIMIT 10")
+
+	whereParams := builder.GetParams()
+	t.Equal(whereParams, []interface{}{"paid", 100, 5, 1, 2})
+}
+
+func (t *TestSuite) TestSubqueries() {
+	paid := New().
+		Select("orders").
+		Fields("user_id").
+		Where("status", "=", "paid")
+
+	open := New().
+		Select("tickets").
+		Fields("id").
+		Where("tickets.user_id", "=", 7)
+
+	maxAge := New().
+		Select("users").
+		Columns(Raw("max(age)")).
+		Where("country", "=", "HU")
+
+	builder := New()
+	builder.SetSQLFlavour(FlavourPgSQL)
+	sql, err := builder.
+		Select("users").
+		Where("active", "=", true).
+		InSub("id", paid).
+		WhereGroup(func(w Where) {
+			w.NotExists(open).
+				OrWhere("role", "=", "admin")
+		}).
+		WhereSub("age", "<", maxAge).
+		Where("id", ">", 10).
+		AsSQL()
+
+	t.Nil(err)
+	t.Equal(sql, "SELECT * FROM \"users\" WHERE \"active\"=$1 AND \"id\" IN (SELECT \"user_id\" FROM \"orders\" WHERE \"status\"=$2) AND (NOT EXISTS (SELECT \"id\" FROM \"tickets\" WHERE \"tickets\".\"user_id\"=$3) OR \"role\"=$4) AND \"age\"<(SELECT max(age) FROM \"users\" WHERE \"country\"=$5) AND \"id\">$6")
+
+	whereParams := builder.GetParams()
+	t.Equal(whereParams, []interface{}{true, "paid", 7, "admin", "HU", 10})
+
+	sql, err = paid.AsSQL()
+	t.Nil(err)
+	t.Equal(sql, "SELECT `user_id` FROM `orders` WHERE `status`=?")
+}
+
+func (t *TestSuite) TestInvalidSubquery() {
+	builder := New()
+	_, err := builder.
+		Select("users").
+		Exists(nil).
+		AsSQL()
+
+	t.ErrorIs(err, ErrInvalidSubquery)
+}
+
+func (t *TestSuite) TestDerivedTables() {
+	totals := New().
+		Select("orders").
+		Columns("user_id", Raw("sum(total)").As("total")).
+		Where("status", "=", "paid").
+		GroupBy("user_id")
+
+	visits := New().
+		Select("visits").
+		Columns("user_id", Raw("count(*)").As("cnt")).
+		Where("year", "=", 2024).
+		GroupBy("user_id")
+
+	builder := New()
+	builder.SetSQLFlavour(FlavourPgSQL)
+	sql, err := builder.
+		SelectFrom(totals, "t").
+		Fields("t.user_id", "t.total", "v.cnt").
+		JoinSub(visits, "v", "v.user_id", "t.user_id", func(w Where) {
+			w.Where("v.cnt", ">", 3)
+		}).
+		Where("t.total", ">", 100).
+		AsSQL()
+
+	t.Nil(err)
+	t.Equal(sql, "SELECT \"t\".\"user_id\",\"t\".\"total\",\"v\".\"cnt\" FROM (SELECT \"user_id\",sum(total) AS \"total\" FROM \"orders\" WHERE \"status\"=$1 GROUP BY \"user_id\") AS \"t\" JOIN (SELECT \"user_id\",count(*) AS \"cnt\" FROM \"visits\" WHERE \"year\"=$2 GROUP BY \"user_id\") AS \"v\" ON \"v\".\"user_id\"=\"t\".\"user_id\" AND \"v\".\"cnt\">$3 WHERE \"t\".\"total\">$4")
+
+	whereParams := builder.GetParams()
+	t.Equal(whereParams, []interface{}{"paid", 2024, 3, 100})
+
+	_, err = builder.SelectFrom(totals, "").AsSQL()
+	t.ErrorIs(err, ErrInvalidIdentifier)
+}
+
+func (t *TestSuite) TestWith() {
+	active := New().
+		Select("users").
+		Fields("id").
+		Where("active", "=", true)
+
+	builder := New()
+	builder.SetSQLFlavour(FlavourPgSQL)
+	sql, err := builder.
+		With("active_users", active).
+		Select("orders").
+		InSub("user_id", New().Select("active_users").Fields("id")).
+		Where("total", ">", 100).
+		AsSQL()
+
+	t.Nil(err)
+	t.Equal(sql, "WITH \"active_users\" AS (SELECT \"id\" FROM \"users\" WHERE \"active\"=$1) SELECT * FROM \"orders\" WHERE \"user_id\" IN (SELECT \"id\" FROM \"active_users\") AND \"total\">$2")
+
+	whereParams := builder.GetParams()
+	t.Equal(whereParams, []interface{}{true, 100})
+
+	sql, err = builder.
+		Select("orders").
+		AsSQL()
+
+	t.Nil(err)
+	t.Equal(sql, "SELECT * FROM \"orders\"")
+
+	sql, err = builder.
+		WithMaterialized("active_users", active).
+		Delete("sessions").
+		InSub("user_id", New().Select("active_users").Fields("id")).
+		AsSQL()
+
+	t.Nil(err)
+	t.Equal(sql, "WITH \"active_users\" AS MATERIALIZED (SELECT \"id\" FROM \"users\" WHERE \"active\"=$1) DELETE FROM \"sessions\" WHERE \"user_id\" IN (SELECT \"id\" FROM \"active_users\")")
+
+	whereParams = builder.GetParams()
+	t.Equal(whereParams, []interface{}{true})
+
+	builder.SetSQLFlavour(FlavourMySQL)
+	_, err = builder.
+		WithMaterialized("active_users", active).
+		Select("active_users").
+		AsSQL()
+
+	t.ErrorIs(err, ErrNotSupported)
+}
+
+func (t *TestSuite) TestWithAfterStatement() {
+	active := New().
+		Select("users").
+		Fields("id").
+		Where("active", "=", true)
+
+	builder := New()
+	sql, err := builder.
+		Select("active_users").
+		With("active_users", active).
+		AsSQL()
+
+	t.Nil(err)
+	t.Equal(sql, "WITH `active_users` AS (SELECT `id` FROM `users` WHERE `active`=?) SELECT * FROM `active_users`")
+
+	whereParams := builder.GetParams()
+	t.Equal(whereParams, []interface{}{true})
+
+	sql, err = builder.
+		Select("orders").
+		AsSQL()
+
+	t.Nil(err)
+	t.Equal(sql, "SELECT * FROM `orders`")
+
+	whereParams = builder.GetParams()
+	t.Empty(whereParams)
+}
+
+func (t *TestSuite) TestWithInDML() {
+	builder := New()
+	sql, err := builder.
+		With("recent", New().Select("imports").Where("created_at", ">", "2024-01-01")).
+		Insert("users").
+		Fields("name").
+		FromSelect(New().Select("recent").Fields("name")).
+		AsSQL()
+
+	t.Nil(err)
+	t.Equal(sql, "INSERT INTO `users` (`name`) WITH `recent` AS (SELECT * FROM `imports` WHERE `created_at`>?) SELECT `name` FROM `recent`")
+
+	_, err = builder.
+		With("recent", New().Select("imports")).
+		Insert("users").
+		Fields("name").
+		Values("John").
+		AsSQL()
+
+	t.ErrorIs(err, ErrNotSupported)
+
+	sql, err = builder.
+		With("old", New().Select("users").Fields("id").Where("age", ">", 99)).
+		Delete("users").
+		WhereSub("id", "=", New().Select("old").Fields("id")).
+		AsSQL()
+
+	t.Nil(err)
+	t.Equal(sql, "WITH `old` AS (SELECT `id` FROM `users` WHERE `age`>?) DELETE FROM `users` WHERE `id`=(SELECT `id` FROM `old`)")
+
+	t.Nil(builder.SetSQLFlavour(FlavourOracle))
+	sql, err = builder.
+		With("recent", New().Select("imports")).
+		Insert("users").
+		Fields("name").
+		FromSelect(New().Select("recent").Fields("name")).
+		AsSQL()
+
+	t.Nil(err)
+	t.Equal(sql, "INSERT INTO \"users\" (\"name\") WITH \"recent\" AS (SELECT * FROM \"imports\") SELECT \"name\" FROM \"recent\"")
+
+	_, err = builder.
+		With("old", New().Select("users")).
+		Update("users").
+		Fields("active").
+		Values(0).
+		AsSQL()
+
+	t.ErrorIs(err, ErrNotSupported)
+
+	_, err = builder.
+		With("old", New().Select("users")).
+		Delete("users").
+		AsSQL()
+
+	t.ErrorIs(err, ErrNotSupported)
+}
+
+func (t *TestSuite) TestWithRecursive() {
+	anchor := New().
+		Select("categories").
+		Fields("id", "parent_id").
+		Where("id", "=", 1)
+
+	recursive := New().
+		SelectAs("categories", "c").
+		Fields("c.id", "c.parent_id").
+		JoinAs("tree", "t", "t.id", "c.parent_id", func(w Where) {
+			w.Where("t.depth", "<", 10)
+		})
+
+	builder := New()
+	sql, err := builder.
+		WithRecursive("tree", []string{"id", "parent_id"}, anchor, recursive).
+		Select("tree").
+		AsSQL()
+
+	t.Nil(err)
+	t.Equal(sql, "WITH RECURSIVE `tree` (`id`,`parent_id`) AS (SELECT `id`,`parent_id` FROM `categories` WHERE `id`=? UNION ALL SELECT `c`.`id`,`c`.`parent_id` FROM `categories` AS `c` JOIN `tree` AS `t` ON `t`.`id`=`c`.`parent_id` AND `t`.`depth`<?) SELECT * FROM `tree`")
+
+	whereParams := builder.GetParams()
+	t.Equal(whereParams, []interface{}{1, 10})
+
+	builder.SetSQLFlavour(FlavourMSSQL)
+	sql, err = builder.
+		WithRecursive("tree", []string{"id", "parent_id"}, anchor, recursive).
+		Select("tree").
+		AsSQL()
+
+	t.Nil(err)
+	t.Equal(sql, "WITH [tree] ([id],[parent_id]) AS (SELECT [id],[parent_id] FROM [categories] WHERE [id]=@p1 UNION ALL SELECT [c].[id],[c].[parent_id] FROM [categories] AS [c] JOIN [tree] AS [t] ON [t].[id]=[c].[parent_id] AND [t].[depth]<@p2) SELECT * FROM [tree]")
+}
+
+func (t *TestSuite) TestUnion() {
+	archived := New().
+		Select("archived_users").
+		Fields("id", "name").
+		Where("deleted", "=", false)
+
+	latest := New().
+		Select("guests").
+		Fields("id", "name").
+		OrderByDesc("id").
+		Limit(5)
+
+	builder := New()
+	builder.SetSQLFlavour(FlavourPgSQL)
+	sql, err := builder.
+		Select("users").
+		Fields("id", "name").
+		Where("active", "=", true).
+		Union(archived).
+		UnionAll(latest).
+		OrderBy("name").
+		Limit(10).
+		AsSQL()
+
+	t.Nil(err)
+	t.Equal(sql, "SELECT \"id\",\"name\" FROM \"users\" WHERE \"active\"=$1 UNION SELECT \"id\",\"name\" FROM \"archived_users\" WHERE \"deleted\"=$2 UNION ALL (SELECT \"id\",\"name\" FROM \"guests\" ORDER BY \"id\" DESC LIMIT 5) ORDER BY \"name\" LIMIT 10")
+
+	whereParams := builder.GetParams()
+	t.Equal(whereParams, []interface{}{true, false})
+
+	builder.SetSQLFlavour(FlavourSqLite)
+	sql, err = builder.AsSQL()
+
+	t.Nil(err)
+	t.Equal(sql, "SELECT \"id\",\"name\" FROM \"users\" WHERE \"active\"=? UNION SELECT \"id\",\"name\" FROM \"archived_users\" WHERE \"deleted\"=? UNION ALL SELECT * FROM (SELECT \"id\",\"name\" FROM \"guests\" ORDER BY \"id\" DESC LIMIT 5) AS \"compound\" ORDER BY \"name\" LIMIT 10")
+
+	builder.SetSQLFlavour(FlavourMSSQL)
+	sql, err = builder.AsSQL()
+
+	t.Nil(err)
+	t.Equal(sql, "SELECT TOP 10 * FROM (SELECT [id],[name] FROM [users] WHERE [active]=@p1 UNION SELECT [id],[name] FROM [archived_users] WHERE [deleted]=@p2 UNION ALL (SELECT TOP 5 [id],[name] FROM [guests] ORDER BY [id] DESC)) AS [compound] ORDER BY [name]")
+}
+
+func (t *TestSuite) TestIntersectExcept() {
+	buyers := New().
+		Select("orders").
+		Fields("user_id").
+		Where("status", "=", "paid")
+
+	banned := New().
+		Select("bans").
+		Fields("user_id")
+
+	builder := New()
+	sql, err := builder.
+		Select("users").
+		FieldAs("id", "user_id").
+		Intersect(buyers).
+		Except(banned).
+		AsSQL()
+
+	t.Nil(err)
+	t.Equal(sql, "SELECT `id` AS `user_id` FROM `users` INTERSECT SELECT `user_id` FROM `orders` WHERE `status`=? EXCEPT SELECT `user_id` FROM `bans`")
+
+	builder.SetDialect(MySQLDialect{Version: 80030})
+	sql, err = builder.AsSQL()
+
+	t.Nil(err)
+	t.Equal(sql, "SELECT DISTINCT * FROM (SELECT `id` AS `user_id` FROM `users`) AS `compound` WHERE EXISTS (SELECT * FROM (SELECT `user_id` FROM `orders` WHERE `status`=?) AS `compound1` WHERE `compound`.`user_id` <=> `compound1`.`user_id`) AND NOT EXISTS (SELECT * FROM (SELECT `user_id` FROM `bans`) AS `compound2` WHERE `compound`.`user_id` <=> `compound2`.`user_id`)")
+
+	whereParams := builder.GetParams()
+	t.Equal(whereParams, []interface{}{"paid"})
+
+	_, err = builder.
+		Select("users").
+		Intersect(buyers).
+		AsSQL()
+
+	t.ErrorIs(err, ErrNotSupported)
+
+	_, err = builder.
+		Select("users").
+		Fields("id").
+		Intersect(buyers).
+		Union(banned).
+		AsSQL()
+
+	t.ErrorIs(err, ErrNotSupported)
+
+	_, err = builder.
+		Select("users").
+		Fields("id", "name").
+		Except(banned).
+		AsSQL()
+
+	t.ErrorIs(err, ErrNotSupported)
+
+	sql, err = builder.
+		Select("users").
+		Fields("id", "email").
+		Except(New().Select("bans").Fields("user_id", "email")).
+		AsSQL()
+
+	t.Nil(err)
+	t.Equal(sql, "SELECT DISTINCT * FROM (SELECT `id`,`email` FROM `users`) AS `compound` WHERE NOT EXISTS (SELECT * FROM (SELECT `user_id`,`email` FROM `bans`) AS `compound1` WHERE `compound`.`id` <=> `compound1`.`user_id` AND `compound`.`email` <=> `compound1`.`email`)")
+}
+
+func (t *TestSuite) TestLike() {
+	builder := New()
+	sql, err := builder.
+		Select("users").
+		Like("name", "J%").
+		NotLike("email", "%@example.com").
+		OrLike("nick", "j_").
+		ILike("city", "budapest").
+		WhereGroup(func(w Where) {
+			w.Contains("bio", "50%_off\\").
+				StartsWith("name", "Jo")
+		}).
+		EndsWith("email", ".hu").
+		AsSQL()
+
+	t.Nil(err)
+	t.Equal(sql, "SELECT * FROM `users` WHERE `name` LIKE ? AND `email` NOT LIKE ? OR `nick` LIKE ? AND LOWER(`city`) LIKE LOWER(?) AND (`bio` LIKE ? ESCAPE '\\\\' AND `name` LIKE ? ESCAPE '\\\\') AND `email` LIKE ? ESCAPE '\\\\'")
+
+	whereParams := builder.GetParams()
+	t.Equal(whereParams, []interface{}{"J%", "%@example.com", "j_", "budapest", "%50\\%\\_off\\\\%", "Jo%", "%.hu"})
+
+	builder.SetSQLFlavour(FlavourPgSQL)
+	sql, err = builder.
+		Select("users").
+		ILike("city", "budapest").
+		Contains("bio", "x").
+		AsSQL()
+
+	t.Nil(err)
+	t.Equal(sql, "SELECT * FROM \"users\" WHERE \"city\" ILIKE $1 AND \"bio\" LIKE $2 ESCAPE '\\'")
+
+	builder.SetSQLFlavour(FlavourMSSQL)
+	sql, err = builder.
+		Select("users").
+		Contains("bio", "[a]_50%").
+		AsSQL()
+
+	t.Nil(err)
+	t.Equal(sql, "SELECT * FROM [users] WHERE [bio] LIKE @p1 ESCAPE '\\'")
+	t.Equal(builder.GetParams(), []interface{}{"%\\[a]\\_50\\%%"})
+
+	sql, err = builder.
+		Select("users").
+		InSub("id", New().Select("authors").Fields("user_id").Contains("name", "a[b")).
+		AsSQL()
+
+	t.Nil(err)
+	t.Equal(sql, "SELECT * FROM [users] WHERE [id] IN (SELECT [user_id] FROM [authors] WHERE [name] LIKE @p1 ESCAPE '\\')")
+	t.Equal(builder.GetParams(), []interface{}{"%a\\[b%"})
+}
+
+func (t *TestSuite) TestInvalidRelation() {
+	builder := New()
+	_, err := builder.
+		Select("users").
+		Where("id", "=", 1).
+		WhereGroup(func(w Where) {
+			w.Where("name", "DROP", "x")
+		}).
+		AsSQL()
+
+	var relationErr *InvalidRelationError
+	t.ErrorAs(err, &relationErr)
+	t.Equal(relationErr.Field, "name")
+	t.Equal(relationErr.Relation, "DROP")
+
+	sql, err := builder.
+		Select("users").
+		Where("id", "=", 1).
+		AsSQL()
+
+	t.Nil(err)
+	t.Equal(sql, "SELECT * FROM `users` WHERE `id`=?")
+}
+
+func (t *TestSuite) TestStrictInvalidRelation() {
+	builder := New().SetStrict(true)
+	t.Panics(func() {
+		builder.Select("users").Where("id", "=>", 1)
+	})
+
+	t.Panics(func() {
+		builder.Select("users").WhereGroup(func(w Where) {
+			w.OrWhere("id", "?", 1)
+		})
+	})
+}
+
+func (t *TestSuite) TestWhereColumn() {
+	builder := New()
+	sql, err := builder.
+		Select("orders").
+		WhereColumn("shipped_at", ">", "ordered_at").
+		OrWhereColumn("orders.total", "<>", "orders.paid").
+		Where("status", "=", "open").
+		AsSQL()
+
+	t.Nil(err)
+	t.Equal(sql, "SELECT * FROM `orders` WHERE `shipped_at`>`ordered_at` OR `orders`.`total`<>`orders`.`paid` AND `status`=?")
+
+	whereParams := builder.GetParams()
+	t.Equal(whereParams, []interface{}{"open"})
+}
+
+func (t *TestSuite) TestJoinOnConditionTree() {
+	builder := New()
+	sql, err := builder.
+		Select("users").
+		LeftJoin("orders", "", "", func(w Where) {
+			w.WhereColumn("orders.user_id", "=", "users.id").
+				WhereColumn("orders.shop_id", "=", "users.shop_id").
+				Where("orders.status", "=", "paid")
+		}).
+		Where("users.active", "=", 1).
+		AsSQL()
+
+	t.Nil(err)
+	t.Equal(sql, "SELECT * FROM `users` LEFT JOIN `orders` ON `orders`.`user_id`=`users`.`id` AND `orders`.`shop_id`=`users`.`shop_id` AND `orders`.`status`=? WHERE `users`.`active`=?")
+
+	whereParams := builder.GetParams()
+	t.Equal(whereParams, []interface{}{"paid", 1})
+}
+
+func (t *TestSuite) TestJoinOn() {
+	builder := New()
+	sql, err := builder.
+		Select("users").
+		JoinOn("orders", func(on On) {
+			on.WhereColumn("orders.user_id", "=", "users.id").
+				OrWhereGroup(func(w Where) {
+					w.WhereColumn("orders.email", "=", "users.email").
+						WhereColumn("orders.created_at", ">=", "users.created_at")
+				})
+		}).
+		LeftJoinOn("coupons", func(on On) {
+			on.WhereColumn("coupons.order_id", "=", "orders.id").
+				Where("coupons.value", ">", 10)
+		}).
+		Join("shops", "shops.id", "orders.shop_id", func(w Where) {}).
+		Where("users.active", "=", 1).
+		AsSQL()
+
+	t.Nil(err)
+	t.Equal(sql, "SELECT * FROM `users` JOIN `orders` ON `orders`.`user_id`=`users`.`id` OR (`orders`.`email`=`users`.`email` AND `orders`.`created_at`>=`users`.`created_at`) LEFT JOIN `coupons` ON `coupons`.`order_id`=`orders`.`id` AND `coupons`.`value`>? JOIN `shops` ON `shops`.`id`=`orders`.`shop_id` WHERE `users`.`active`=?")
+
+	whereParams := builder.GetParams()
+	t.Equal(whereParams, []interface{}{10, 1})
+}
+
+func (t *TestSuite) TestJoinUsing() {
+	builder := New()
+	sql, err := builder.
+		Select("orders").
+		JoinUsing("shipments", "order_id", "shop_id").
+		AsSQL()
+
+	t.Nil(err)
+	t.Equal(sql, "SELECT * FROM `orders` JOIN `shipments` USING (`order_id`,`shop_id`)")
+
+	t.Nil(builder.SetSQLFlavour(FlavourMSSQL))
+	sql, err = builder.
+		SelectAs("orders", "o").
+		JoinUsing("shipments", "order_id", "shop_id").
+		AsSQL()
+
+	t.Nil(err)
+	t.Equal(sql, "SELECT * FROM [orders] AS [o] JOIN [shipments] ON [shipments].[order_id]=[o].[order_id] AND [shipments].[shop_id]=[o].[shop_id]")
+}
+
+func (t *TestSuite) TestEmptyJoinCondition() {
+	builder := New()
+	_, err := builder.
+		Select("a").
+		JoinOn("b", func(on On) {}).
+		AsSQL()
+
+	t.ErrorIs(err, ErrEmptyJoinCondition)
+
+	_, err = builder.
+		Select("a").
+		Join("b", "", "", func(w Where) {}).
+		AsSQL()
+
+	t.ErrorIs(err, ErrEmptyJoinCondition)
+}
+
+func (t *TestSuite) TestWhereStartingWithGroup() {
+	builder := New()
+	sql, err := builder.
+		Select("users").
+		WhereGroup(func(w Where) {
+			w.Where("a", "=", 1).
+				OrWhere("b", "=", 2)
+		}).
+		Where("c", "=", 3).
+		AsSQL()
+
+	t.Nil(err)
+	t.Equal(sql, "SELECT * FROM `users` WHERE (`a`=? OR `b`=?) AND `c`=?")
+}
+
+func (t *TestSuite) TestMultiRowInsert() {
+	builder := New()
+	sql, err := builder.Insert("users").
+		Fields("name", "age").
+		Rows([]interface{}{"John", 30}, []interface{}{"Jane", 25}).
+		AddRow("Joe", 40).
+		AsSQL()
+
+	t.Nil(err)
+	t.Equal(sql, "INSERT INTO `users` (`name`,`age`) VALUES (?,?),(?,?),(?,?)")
+
+	pars := builder.GetParams()
+	t.Equal(pars, []interface{}{"John", 30, "Jane", 25, "Joe", 40})
+
+	_, err = builder.Insert("users").
+		Fields("name", "age").
+		AddRow("John", 30).
+		AddRow("Jane").
+		AsSQL()
+
+	t.ErrorIs(err, errFieldCountMismatch)
+}
+
+func (t *TestSuite) TestInsertBatchesEdgeCases() {
+	builder := New()
+	t.Nil(builder.SetSQLFlavour(FlavourFirebirdSQL))
+
+	batches, err := builder.InsertBatches("users", []string{"id"}, nil, BatchOptions{})
+	t.Nil(err)
+	t.Len(batches, 0)
+
+	rows := make([][]interface{}, 300)
+	for i := range rows {
+		rows[i] = []interface{}{i}
+	}
+
+	builder.Select("users").Where("id", "=", 1)
+	batches, err = builder.InsertBatches("users", []string{"id"}, rows, BatchOptions{})
+	t.Nil(err)
+	t.Len(batches, 2)
+	t.Len(batches[0].Params, 254)
+	t.Len(batches[1].Params, 46)
+
+	sql, err := builder.AsSQL()
+	t.Nil(err)
+	t.Equal(sql, "SELECT * FROM \"users\" WHERE \"id\"=?")
+}
+
+func (t *TestSuite) TestInsertBatches() {
+	rows := make([][]interface{}, 2500)
+	for i := range rows {
+		rows[i] = []interface{}{i, "name"}
+	}
+
+	builder := New()
+	t.Nil(builder.SetSQLFlavour(FlavourMSSQL))
+
+	batches, err := builder.InsertBatches("users", []string{"id", "name"}, rows, BatchOptions{})
+	t.Nil(err)
+	t.Len(batches, 3)
+	t.Len(batches[0].Params, 2000)
+	t.Len(batches[2].Params, 1000)
+	t.Equal(batches[2].Params[0], 2000)
+	t.Contains(batches[2].SQL, "(@p999,@p1000)")
+
+	t.Nil(builder.SetDialect(SQLiteDialect{Version: 3031000}))
+	batches, err = builder.InsertBatches("users", []string{"id", "name"}, rows, BatchOptions{})
+	t.Nil(err)
+	t.Len(batches, 6)
+	t.Len(batches[0].Params, 998)
+
+	batches, err = builder.InsertBatches("users", []string{"id", "name"}, rows[:3], BatchOptions{MaxSQLSize: 55})
+	t.Nil(err)
+	t.Len(batches, 2)
+	t.Equal(batches[0].SQL, "INSERT INTO \"users\" (\"id\",\"name\") VALUES (?,?)")
+	t.Equal(batches[1].SQL, "INSERT INTO \"users\" (\"id\",\"name\") VALUES (?,?),(?,?)")
+
+	_, err = builder.InsertBatches("users", []string{"id", "name"}, rows[:3], BatchOptions{MaxSQLSize: 10})
+	t.ErrorIs(err, ErrBatchTooLarge)
+
+	_, err = builder.InsertBatches("users", []string{"id", "name"}, rows[:3], BatchOptions{MaxParams: 1})
+	t.ErrorIs(err, ErrBatchTooLarge)
+}
+
+func (t *TestSuite) TestInsertFromSelect() {
+	builder := New()
+	t.Nil(builder.SetSQLFlavour(FlavourPgSQL))
+
+	staging := New().
+		Select("staging").
+		Fields("s.name", "s.email").
+		JoinAs("batches", "b", "b.id", "s.batch_id", func(w Where) {
+			w.Where("b.status", "=", "ready")
+		}).
+		Where("s.valid", "=", true)
+
+	sql, err := builder.
+		With("recent", New().Select("imports").Where("created_at", ">", "2024-01-01")).
+		Insert("users").
+		Fields("name", "email").
+		FromSelect(staging).
+		AsSQL()
+
+	t.Nil(err)
+	t.Equal(sql, "WITH \"recent\" AS (SELECT * FROM \"imports\" WHERE \"created_at\">$1) INSERT INTO \"users\" (\"name\",\"email\") SELECT \"s\".\"name\",\"s\".\"email\" FROM \"staging\" JOIN \"batches\" AS \"b\" ON \"b\".\"id\"=\"s\".\"batch_id\" AND \"b\".\"status\"=$2 WHERE \"s\".\"valid\"=$3")
+
+	pars := builder.GetParams()
+	t.Equal(pars, []interface{}{"2024-01-01", "ready", true})
+}
@@ -0,0 +1,180 @@
+package builder
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	ErrInvalidColumn = errors.New("invalid column, expected string or RawExpr")
+)
+
+// RawExpr is an SQL expression rendered verbatim, like count(*). Created by Raw
+type RawExpr struct {
+	sql    string
+	alias  string
+	params []interface{}
+}
+
+// Raw creates an SQL expression which is not quoted. When params are given, every ? in the expression is a binding parameter,
+// rendered in the style of the SQL flavour
+func Raw(sql string, params ...interface{}) RawExpr {
+	return RawExpr{sql: sql, params: params}
+}
+
+// As sets the alias of the expression, like count(*) AS `cnt`
+func (r RawExpr) As(alias string) RawExpr {
+	r.alias = alias
+	return r
+}
+
+// column is an entry of the field list of a SELECT, INSERT or UPDATE statement
+type column struct {
+	name   string
+	alias  string
+	raw    bool
+	params []interface{}
+	err    error
+}
+
+func newColumns(names []string, raw bool) []*column {
+	columns := make([]*column, len(names))
+	for i, name := range names {
+		columns[i] = &column{name: name, raw: raw}
+	}
+
+	return columns
+}
+
+// Columns sets the field list from a mix of field names, which are quoted, and Raw expressions, which are rendered verbatim
+func (b *Build) Columns(columns ...interface{}) Builder {
+	b.fields = make([]*column, len(columns))
+	for i, c := range columns {
+		switch v := c.(type) {
+		case string:
+			b.fields[i] = &column{name: v}
+		case RawExpr:
+			b.fields[i] = &column{name: v.sql, alias: v.alias, raw: true, params: v.params}
+		default:
+			b.fields[i] = &column{err: fmt.Errorf("%w: %T", ErrInvalidColumn, c)}
+		}
+	}
+
+	return b
+}
+
+// FieldAs adds a field with an alias, like `field` AS `alias`
+func (b *Build) FieldAs(field, alias string) Builder {
+	b.fields = append(b.fields, &column{name: field, alias: alias})
+	return b
+}
+
+// getColumnNames renders the field list without aliases, as used by INSERT
+func (b *Build) getColumnNames() string {
+	strBuilder := &strings.Builder{}
+	for i, c := range b.fields {
+		if i > 0 {
+			strBuilder.WriteString(",")
+		}
+		strBuilder.WriteString(b.getColumnName(c))
+	}
+
+	return strBuilder.String()
+}
+
+func (b *Build) getColumnList(columns []*column) string {
+	strBuilder := &strings.Builder{}
+	for i, c := range columns {
+		if i > 0 {
+			strBuilder.WriteString(",")
+		}
+		strBuilder.WriteString(b.getColumnName(c))
+
+		if c.alias != "" {
+			builderConcat(strBuilder, " AS ", b.quoteAlias(c.alias))
+		}
+	}
+
+	return strBuilder.String()
+}
+
+func (b *Build) getColumnName(c *column) string {
+	if c.err != nil {
+		b.setError(c.err)
+		return ""
+	}
+
+	if !c.raw {
+		return b.quote(c.name)
+	}
+
+	if len(c.params) == 0 {
+		return c.name
+	}
+
+	return b.bindRawParams(c.name, len(c.params))
+}
+
+// bindRawParams replaces the ? characters of a raw expression with binding parameters,
+// recording an error when their count differs from the count of the params
+func (b *Build) bindRawParams(sql string, paramCount int) string {
+	if placeholders := strings.Count(sql, "?"); placeholders != paramCount {
+		b.setError(fmt.Errorf("%w: %q has %d placeholders for %d params", ErrInvalidColumn, sql, placeholders, paramCount))
+	}
+
+	strBuilder := &strings.Builder{}
+	for _, r := range sql {
+		if r == '?' {
+			strBuilder.WriteString(b.getBindingParameter())
+			continue
+		}
+		strBuilder.WriteRune(r)
+	}
+
+	return strBuilder.String()
+}
+
+// checkColumnParams rejects Raw expressions with params in the field list of INSERT and UPDATE,
+// only SELECT collects their params
+func (b *Build) checkColumnParams() error {
+	for _, c := range b.fields {
+		if len(c.params) > 0 {
+			return fmt.Errorf("%w: Raw with params is only valid in SELECT, got %q", ErrInvalidColumn, c.name)
+		}
+	}
+
+	return nil
+}
+
+func (b *Build) getColumnParams() []interface{} {
+	var pars []interface{}
+	for _, c := range b.fields {
+		pars = append(pars, c.params...)
+	}
+
+	return pars
+}
+
+// getSourceReference renders a table name or a derived table for FROM or JOIN, followed by its alias if set.
+// Derived tables always need an alias
+func (b *Build) getSourceReference(tableName string, sub Builder, alias string) string {
+	if sub != nil {
+		return b.dialect.TableAlias("("+b.generateSubSQL(sub)+")", b.quoteAlias(alias))
+	}
+
+	if alias == "" {
+		return b.quote(tableName)
+	}
+
+	return b.dialect.TableAlias(b.quote(tableName), b.quoteAlias(alias))
+}
+
+// quoteAlias quotes an alias, which unlike quote never splits on dots
+func (b *Build) quoteAlias(alias string) string {
+	if err := b.dialect.ValidateIdentifier(alias); err != nil {
+		b.setError(err)
+	}
+
+	return b.dialect.QuoteIdentifier(alias)
+}
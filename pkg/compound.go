@@ -0,0 +1,204 @@
+package builder
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	compoundUnion     = "UNION"
+	compoundUnionAll  = "UNION ALL"
+	compoundIntersect = "INTERSECT"
+	compoundExcept    = "EXCEPT"
+	compoundAlias     = "compound"
+)
+
+// compound is a query combined with the main SELECT by UNION, INTERSECT or EXCEPT
+type compound struct {
+	operator string
+	query    Builder
+}
+
+// Union combines the select with other selects with UNION. OrderBy, Limit and Offset of this builder apply to the whole compound
+func (b *Build) Union(others ...Builder) Builder {
+	return b.appendCompound(compoundUnion, others)
+}
+
+// UnionAll combines the select with other selects with UNION ALL
+func (b *Build) UnionAll(others ...Builder) Builder {
+	return b.appendCompound(compoundUnionAll, others)
+}
+
+// Intersect combines the select with other selects with INTERSECT, emulated with EXISTS on MySQL versions lacking it
+func (b *Build) Intersect(others ...Builder) Builder {
+	return b.appendCompound(compoundIntersect, others)
+}
+
+// Except combines the select with other selects with EXCEPT, emulated with NOT EXISTS on MySQL versions lacking it
+func (b *Build) Except(others ...Builder) Builder {
+	return b.appendCompound(compoundExcept, others)
+}
+
+func (b *Build) appendCompound(operator string, others []Builder) Builder {
+	for _, other := range others {
+		b.compounds = append(b.compounds, &compound{operator: operator, query: other})
+	}
+
+	return b
+}
+
+func (b *Build) generateCompoundSQL(paginationPrefix, paginationSuffix string) string {
+	var body string
+	if b.hasIntersectOrExcept() && !b.dialect.Supports(FeatureIntersectExcept) {
+		body = b.generateEmulatedCompound()
+	} else {
+		builder := &strings.Builder{}
+		builder.WriteString(b.generateSelectCore(""))
+		for _, c := range b.compounds {
+			builderConcat(builder, " ", c.operator, " ", b.generateCompoundMember(c.query))
+		}
+		body = builder.String()
+	}
+
+	if paginationPrefix != "" {
+		body = "SELECT " + paginationPrefix + " * FROM " + b.dialect.TableAlias("("+body+")", b.quoteAlias(compoundAlias))
+	}
+
+	return body + b.generateSelectTail(paginationSuffix)
+}
+
+// generateCompoundMember renders a member, wrapping it when its own ORDER BY, pagination or compound would leak into the outer one
+func (b *Build) generateCompoundMember(q Builder) string {
+	sql := b.generateSubSQL(q)
+	s, ok := q.(*Build)
+	if !ok || !s.isCompoundSensitive() {
+		return sql
+	}
+
+	if b.dialect.Supports(FeatureCompoundParentheses) {
+		return "(" + sql + ")"
+	}
+
+	return "SELECT * FROM " + b.dialect.TableAlias("("+sql+")", b.quoteAlias(compoundAlias))
+}
+
+func (b *Build) isCompoundSensitive() bool {
+	return len(b.orderBy) > 0 || b.limit > 0 || b.offset > 0 || len(b.compounds) > 0
+}
+
+func (b *Build) hasIntersectOrExcept() bool {
+	for _, c := range b.compounds {
+		if c.operator == compoundIntersect || c.operator == compoundExcept {
+			return true
+		}
+	}
+
+	return false
+}
+
+// generateEmulatedCompound renders INTERSECT and EXCEPT as SELECT DISTINCT * FROM (...) WHERE EXISTS / NOT EXISTS (...),
+// comparing the columns with IS NOT DISTINCT FROM of the dialect, so NULLs match like in INTERSECT and EXCEPT
+func (b *Build) generateEmulatedCompound() string {
+	columns, ok := b.getCompoundColumns()
+	if !ok {
+		b.setError(fmt.Errorf("%w: INTERSECT/EXCEPT emulation needs named fields", ErrNotSupported))
+		return ""
+	}
+
+	format, ok := b.dialect.Operator(IsNotDistinctFrom)
+	if !ok {
+		b.setError(fmt.Errorf("%w: INTERSECT/EXCEPT emulation needs operator %s", ErrNotSupported, IsNotDistinctFrom))
+		return ""
+	}
+
+	builder := &strings.Builder{}
+	builderConcat(
+		builder,
+		"SELECT DISTINCT * FROM ",
+		b.dialect.TableAlias("("+b.generateSelectCore("")+")", b.quoteAlias(compoundAlias)),
+		" ", tokenWhere, " ",
+	)
+
+	for i, c := range b.compounds {
+		if i > 0 {
+			builderConcat(builder, " ", operatorAnd, " ")
+		}
+
+		switch c.operator {
+		case compoundIntersect:
+			builderConcat(builder, "EXISTS (", b.generateEmulatedMember(c.query, i, columns, format), ")")
+		case compoundExcept:
+			builderConcat(builder, "NOT EXISTS (", b.generateEmulatedMember(c.query, i, columns, format), ")")
+		default:
+			b.setError(fmt.Errorf("%w: %s combined with emulated INTERSECT/EXCEPT", ErrNotSupported, c.operator))
+		}
+	}
+
+	return builder.String()
+}
+
+// generateEmulatedMember renders SELECT * FROM (member) WHERE matching the columns of the member to the main select by position
+func (b *Build) generateEmulatedMember(q Builder, i int, columns []string, format string) string {
+	var memberColumns []string
+	s, ok := q.(*Build)
+	if ok {
+		memberColumns, ok = s.getCompoundColumns()
+	}
+
+	if !ok || len(memberColumns) != len(columns) {
+		b.setError(fmt.Errorf("%w: INTERSECT/EXCEPT emulation needs the same number of named fields in each select", ErrNotSupported))
+		return ""
+	}
+
+	alias := compoundAlias + strconv.Itoa(i+1)
+	builder := &strings.Builder{}
+	builderConcat(
+		builder,
+		"SELECT * FROM ",
+		b.dialect.TableAlias("("+b.generateSubSQL(q)+")", b.quoteAlias(alias)),
+		" ", tokenWhere, " ",
+	)
+
+	for j, column := range columns {
+		if j > 0 {
+			builderConcat(builder, " ", operatorAnd, " ")
+		}
+
+		builder.WriteString(fmt.Sprintf(format, b.quote(compoundAlias+"."+column), b.quote(alias+"."+memberColumns[j])))
+	}
+
+	return builder.String()
+}
+
+// getCompoundColumns returns the names of the selected columns, if all of them are known
+func (b *Build) getCompoundColumns() ([]string, bool) {
+	if len(b.fields) == 0 {
+		return nil, false
+	}
+
+	columns := make([]string, len(b.fields))
+	for i, c := range b.fields {
+		switch {
+		case c.alias != "":
+			columns[i] = c.alias
+		case c.raw || c.err != nil || c.name == "*" || strings.HasSuffix(c.name, ".*"):
+			return nil, false
+		default:
+			columns[i] = c.name[strings.LastIndex(c.name, ".")+1:]
+		}
+	}
+
+	return columns, true
+}
+
+func (b *Build) getCompoundParams() []interface{} {
+	var pars []interface{}
+	for _, c := range b.compounds {
+		if c.query != nil {
+			pars = append(pars, b.getSubParams(c.query)...)
+		}
+	}
+
+	return pars
+}
@@ -16,7 +16,8 @@ func (b *Build) generateDeleteSQL() (string, error) {
 	builder := &strings.Builder{}
 	builderConcat(
 		builder,
-		"DELETE FROM ", b.fieldQuote, b.tableName, b.fieldQuote,
+		"DELETE FROM ", b.quote(b.tableName),
+		b.generateOutput(outputDeleted),
 	)
 
 	whereSQL := b.generateWhere(b.where)
@@ -26,6 +27,7 @@ func (b *Build) generateDeleteSQL() (string, error) {
 			" ", tokenWhere, " ", whereSQL,
 		)
 	}
+	builder.WriteString(b.generateReturning())
 
 	return builder.String(), nil
 }
@@ -0,0 +1,675 @@
+package builder
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	// UpsertNone is reported by dialects without any conflict handling for INSERT
+	UpsertNone = 0
+	// UpsertOnConflict renders INSERT ... ON CONFLICT (...) DO UPDATE / DO NOTHING
+	UpsertOnConflict = 1
+	// UpsertOnDuplicateKey renders INSERT ... ON DUPLICATE KEY UPDATE
+	UpsertOnDuplicateKey = 2
+	// UpsertUpdateOrInsert renders UPDATE OR INSERT ... MATCHING (...)
+	UpsertUpdateOrInsert = 3
+	// UpsertMerge renders a MERGE statement
+	UpsertMerge = 4
+
+	// MultiRowValues renders multi-row inserts as INSERT INTO ... VALUES (...),(...)
+	MultiRowValues = 0
+	// MultiRowInsertAll renders multi-row inserts as INSERT ALL INTO ... VALUES (...) INTO ... VALUES (...) SELECT 1 FROM dummy
+	MultiRowInsertAll = 1
+	// MultiRowUnionAll renders multi-row inserts as INSERT INTO ... SELECT ... FROM dummy UNION ALL SELECT ... FROM dummy
+	MultiRowUnionAll = 2
+
+	// ReturningNone is reported by dialects which cannot return the affected rows
+	ReturningNone = 0
+	// ReturningClause renders a trailing RETURNING clause
+	ReturningClause = 1
+	// ReturningOutput renders an OUTPUT INSERTED.x / DELETED.x clause
+	ReturningOutput = 2
+
+	// FeatureNullsOrdering is the support of NULLS FIRST / NULLS LAST in ORDER BY
+	FeatureNullsOrdering = 1
+	// FeatureRecursiveKeyword is the need of the RECURSIVE keyword for recursive common table expressions
+	FeatureRecursiveKeyword = 2
+	// FeatureMaterializedCTE is the support of WITH ... AS MATERIALIZED
+	FeatureMaterializedCTE = 3
+	// FeatureWithInsert is the support of common table expressions in front of INSERT, like WITH ... INSERT INTO ...
+	FeatureWithInsert = 4
+	// FeatureWithUpdateDelete is the support of common table expressions in front of UPDATE and DELETE
+	FeatureWithUpdateDelete = 5
+	// FeatureIntersectExcept is the support of INTERSECT and EXCEPT compound queries
+	FeatureIntersectExcept = 6
+	// FeatureCompoundParentheses is the support of parenthesized members in compound queries, like (SELECT ... LIMIT 1) UNION (...)
+	FeatureCompoundParentheses = 7
+	// FeatureRowValues is the support of row value comparisons, like (`a`,`b`) IN (SELECT ...)
+	FeatureRowValues = 8
+	// FeatureILike is the support of case insensitive ILIKE
+	FeatureILike = 9
+	// FeatureJoinUsing is the support of JOIN ... USING (columns)
+	FeatureJoinUsing = 10
+	// FeatureRightJoin is the support of RIGHT JOIN
+	FeatureRightJoin = 11
+	// FeatureFullJoin is the support of FULL JOIN
+	FeatureFullJoin = 12
+	// FeatureNaturalJoin is the support of NATURAL JOIN
+	FeatureNaturalJoin = 13
+	// FeatureLateral is the support of CROSS JOIN LATERAL (SELECT ...)
+	FeatureLateral = 14
+	// FeatureCrossApply is the support of CROSS APPLY (SELECT ...), used for lateral joins without LATERAL
+	FeatureCrossApply = 15
+	// FeatureInsertRowAlias is the support of INSERT ... VALUES (...) AS alias ON DUPLICATE KEY UPDATE x=alias.x
+	FeatureInsertRowAlias = 16
+	// FeatureMergeUpdateWhere is the need of WHEN MATCHED THEN UPDATE SET ... WHERE instead of WHEN MATCHED AND ... THEN UPDATE in MERGE
+	FeatureMergeUpdateWhere = 17
+	// FeatureMergeTerminator is the need of a semicolon at the end of MERGE statements
+	FeatureMergeTerminator = 18
+	// FeatureUpsertSelectWhere is the need of a WHERE in the SELECT of INSERT ... SELECT ... ON CONFLICT,
+	// otherwise ON CONFLICT is parsed as a join constraint
+	FeatureUpsertSelectWhere = 19
+	// FeatureUpdateReturning is the support of returning the updated rows, the others are returned according to ReturningStyle
+	FeatureUpdateReturning = 20
+	// FeatureUpsertWithoutTarget is the support of ON CONFLICT DO UPDATE without conflict columns
+	FeatureUpsertWithoutTarget = 21
+
+	// FirebirdVersion2 paginates with ROWS m TO n
+	FirebirdVersion2 = 2
+	// FirebirdVersion3 paginates with OFFSET m ROWS FETCH NEXT n ROWS ONLY
+	FirebirdVersion3 = 3
+	// FirebirdVersion4 adds LATERAL derived tables
+	FirebirdVersion4 = 4
+
+	// MySQLVersionIntersect is the first MySQL version supporting INTERSECT and EXCEPT (8.0.31)
+	MySQLVersionIntersect = 80031
+	// MySQLVersionLateral is the first MySQL version supporting LATERAL derived tables (8.0.14)
+	MySQLVersionLateral = 80014
+	// MySQLVersionRowAlias is the first MySQL version supporting row aliases in INSERT, deprecating VALUES() (8.0.20)
+	MySQLVersionRowAlias = 80020
+
+	// SQLiteVersionFullJoin is the first SQLite version supporting RIGHT and FULL JOIN (3.39.0)
+	SQLiteVersionFullJoin = 3039000
+	// SQLiteVersionMaxParams is the first SQLite version allowing 32766 binding parameters instead of 999 (3.32.0)
+	SQLiteVersionMaxParams = 3032000
+	// SQLiteVersionReturning is the first SQLite version supporting RETURNING (3.35.0)
+	SQLiteVersionReturning = 3035000
+	// SQLiteVersionUpsertWithoutTarget is the first SQLite version supporting ON CONFLICT DO UPDATE without conflict columns (3.35.0)
+	SQLiteVersionUpsertWithoutTarget = 3035000
+
+	// MariaDBVersionIntersect is the first MariaDB version supporting INTERSECT and EXCEPT (10.3.0)
+	MariaDBVersionIntersect = 100300
+	// MariaDBVersionReturning is the first MariaDB version supporting RETURNING in INSERT (10.5.0)
+	MariaDBVersionReturning = 100500
+)
+
+// Dialect describes the syntax differences between SQL engines. The builder delegates every engine specific decision to it,
+// so a custom engine can be supported by implementing this interface and passing it to SetDialect.
+// Embed BaseDialect, or one of the built in dialects, to inherit sensible defaults and override only what differs.
+type Dialect interface {
+	// QuoteIdentifier quotes a single identifier, like a table or a field name, escaping the quote characters within
+	QuoteIdentifier(name string) string
+	// ValidateIdentifier returns an error wrapping ErrInvalidIdentifier if the engine cannot accept the identifier even when quoted
+	ValidateIdentifier(name string) error
+	// Placeholder renders the binding parameter for the given 1 based position
+	Placeholder(position int) string
+	// Pagination returns the SQL rendered after the SELECT keyword (prefix) and at the end of the statement (suffix)
+	// for the given limit and offset, where 0 means not set
+	Pagination(limit, offset int) (prefix, suffix string)
+	// PaginationOrderBy returns the ORDER BY expression injected when the pagination suffix requires an ordered statement
+	// but none was set, or an empty string when no ordering is required
+	PaginationOrderBy() string
+	// TableAlias renders the already quoted table name followed by its already quoted alias
+	TableAlias(table, alias string) string
+	// LikeEscape renders the string literal of the backslash escape character of LIKE patterns, used in ESCAPE clauses
+	LikeEscape() string
+	// EscapeLike escapes the wildcards of a LIKE pattern and the escape character itself with backslash
+	EscapeLike(value string) string
+	// MultiRowStyle returns one of the MultiRow* constants
+	MultiRowStyle() int
+	// DummyTable returns the one row table used to select expressions, like DUAL, or an empty string when FROM is optional
+	DummyTable() string
+	// MaxParams returns the maximum number of binding parameters of a statement, or 0 if there is no limit
+	MaxParams() int
+	// MaxInsertRows returns the maximum number of rows of a multi-row INSERT, or 0 if there is no limit
+	MaxInsertRows() int
+	// UpsertStyle returns one of the Upsert* constants
+	UpsertStyle() int
+	// ReturningStyle returns one of the Returning* constants
+	ReturningStyle() int
+	// Operator returns the format of the operator, where the first %s is the field and the second is the value,
+	// or false if the SQL engine cannot express it
+	Operator(op Op) (format string, ok bool)
+	// Supports reports if the SQL engine supports the given Feature* constant, the builder emulates or rejects the unsupported ones
+	Supports(feature int) bool
+}
+
+// BaseDialect is an ANSI SQL dialect: double quoted identifiers, ? placeholders and LIMIT/OFFSET pagination
+type BaseDialect struct{}
+
+// QuoteIdentifier wraps the name in double quotes, doubling the double quotes within
+func (BaseDialect) QuoteIdentifier(name string) string {
+	return "\"" + strings.ReplaceAll(name, "\"", "\"\"") + "\""
+}
+
+// ValidateIdentifier rejects empty identifiers and identifiers containing NUL bytes
+func (BaseDialect) ValidateIdentifier(name string) error {
+	if name == "" {
+		return fmt.Errorf("%w: empty identifier", ErrInvalidIdentifier)
+	}
+
+	if strings.ContainsRune(name, 0) {
+		return fmt.Errorf("%w: %q contains NUL byte", ErrInvalidIdentifier, name)
+	}
+
+	return nil
+}
+
+// Placeholder returns ?, regardless of the position
+func (BaseDialect) Placeholder(_ int) string {
+	return "?"
+}
+
+// Pagination renders LIMIT x OFFSET y
+func (BaseDialect) Pagination(limit, offset int) (prefix, suffix string) {
+	return "", limitOffset(limit, offset)
+}
+
+// PaginationOrderBy returns an empty string, LIMIT/OFFSET does not require ORDER BY
+func (BaseDialect) PaginationOrderBy() string {
+	return ""
+}
+
+// TableAlias renders table AS alias
+func (BaseDialect) TableAlias(table, alias string) string {
+	return table + " AS " + alias
+}
+
+// LikeEscape renders '\'
+func (BaseDialect) LikeEscape() string {
+	return `'\'`
+}
+
+// EscapeLike escapes \, % and _
+func (BaseDialect) EscapeLike(value string) string {
+	return escapeLike(value)
+}
+
+// MultiRowStyle returns MultiRowValues
+func (BaseDialect) MultiRowStyle() int {
+	return MultiRowValues
+}
+
+// DummyTable returns an empty string, SELECT does not need FROM
+func (BaseDialect) DummyTable() string {
+	return ""
+}
+
+// MaxParams returns 0, no limit
+func (BaseDialect) MaxParams() int {
+	return 0
+}
+
+// MaxInsertRows returns 0, no limit
+func (BaseDialect) MaxInsertRows() int {
+	return 0
+}
+
+// UpsertStyle reports no upsert support
+func (BaseDialect) UpsertStyle() int {
+	return UpsertNone
+}
+
+// ReturningStyle reports no RETURNING support
+func (BaseDialect) ReturningStyle() int {
+	return ReturningNone
+}
+
+// Operator renders the ANSI comparison operators, LIKE, IS DISTINCT FROM and emulates ILIKE with LOWER()
+func (BaseDialect) Operator(op Op) (string, bool) {
+	format, ok := ansiOperators[op]
+	return format, ok
+}
+
+// Supports reports the ANSI features: NULLS ordering, WITH RECURSIVE, INTERSECT/EXCEPT, parenthesized compound members, row values,
+// JOIN USING, RIGHT, FULL, NATURAL and LATERAL joins and returning the updated rows
+func (BaseDialect) Supports(feature int) bool {
+	switch feature {
+	case FeatureNullsOrdering, FeatureRecursiveKeyword, FeatureIntersectExcept, FeatureCompoundParentheses, FeatureRowValues,
+		FeatureJoinUsing, FeatureRightJoin, FeatureFullJoin, FeatureNaturalJoin, FeatureLateral, FeatureUpdateReturning:
+		return true
+	default:
+		return false
+	}
+}
+
+// SQLiteDialect is the dialect of SQLite.
+// Version is the SQLite version in the form of SQLITE_VERSION_NUMBER (3039000 for 3.39.0), the zero value means the latest version
+type SQLiteDialect struct {
+	BaseDialect
+	Version int
+}
+
+// Supports reports WITH RECURSIVE, materialized common table expressions, WITH in front of INSERT, UPDATE and DELETE,
+// INTERSECT/EXCEPT, row values, JOIN USING, NATURAL JOIN, the WHERE of upsert selects, returning the updated rows,
+// RIGHT and FULL JOIN from SQLiteVersionFullJoin and upserts without conflict columns from SQLiteVersionUpsertWithoutTarget.
+// NULLS ordering is emulated
+func (d SQLiteDialect) Supports(feature int) bool {
+	switch feature {
+	case FeatureRecursiveKeyword, FeatureMaterializedCTE, FeatureWithInsert, FeatureWithUpdateDelete, FeatureIntersectExcept,
+		FeatureRowValues, FeatureJoinUsing, FeatureNaturalJoin, FeatureUpsertSelectWhere, FeatureUpdateReturning:
+		return true
+	case FeatureRightJoin, FeatureFullJoin:
+		return d.Version == 0 || d.Version >= SQLiteVersionFullJoin
+	case FeatureUpsertWithoutTarget:
+		return d.Version == 0 || d.Version >= SQLiteVersionUpsertWithoutTarget
+	default:
+		return false
+	}
+}
+
+// Operator adds REGEXP, which needs a user function registered in SQLite, and renders IS DISTINCT FROM as IS NOT
+func (SQLiteDialect) Operator(op Op) (string, bool) {
+	return lookupOperator(sqLiteOperators, op)
+}
+
+// MaxParams returns 32766, or 999 before SQLiteVersionMaxParams
+func (d SQLiteDialect) MaxParams() int {
+	if d.Version > 0 && d.Version < SQLiteVersionMaxParams {
+		return 999
+	}
+
+	return 32766
+}
+
+// UpsertStyle returns UpsertOnConflict
+func (SQLiteDialect) UpsertStyle() int {
+	return UpsertOnConflict
+}
+
+// ReturningStyle returns ReturningClause, or ReturningNone before SQLiteVersionReturning
+func (d SQLiteDialect) ReturningStyle() int {
+	if d.Version > 0 && d.Version < SQLiteVersionReturning {
+		return ReturningNone
+	}
+
+	return ReturningClause
+}
+
+// MySQLDialect is the dialect of MySQL.
+// Version is the MySQL version in the form of MYSQL_VERSION_ID (80031 for 8.0.31), the zero value means the latest version
+type MySQLDialect struct {
+	BaseDialect
+	Version int
+}
+
+// QuoteIdentifier wraps the name in backticks, doubling the backticks within
+func (MySQLDialect) QuoteIdentifier(name string) string {
+	return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+}
+
+// Supports reports WITH RECURSIVE, WITH in front of UPDATE and DELETE, parenthesized compound members, row values, JOIN USING,
+// RIGHT and NATURAL JOIN, INTERSECT/EXCEPT from MySQLVersionIntersect, LATERAL from MySQLVersionLateral
+// and insert row aliases from MySQLVersionRowAlias. NULLS ordering is emulated
+func (d MySQLDialect) Supports(feature int) bool {
+	switch feature {
+	case FeatureRecursiveKeyword, FeatureWithUpdateDelete, FeatureCompoundParentheses, FeatureRowValues, FeatureJoinUsing,
+		FeatureRightJoin, FeatureNaturalJoin:
+		return true
+	case FeatureIntersectExcept:
+		return d.Version == 0 || d.Version >= MySQLVersionIntersect
+	case FeatureLateral:
+		return d.Version == 0 || d.Version >= MySQLVersionLateral
+	case FeatureInsertRowAlias:
+		return d.Version == 0 || d.Version >= MySQLVersionRowAlias
+	default:
+		return false
+	}
+}
+
+// LikeEscape renders '\\', backslash is an escape character in MySQL string literals
+func (MySQLDialect) LikeEscape() string {
+	return `'\\'`
+}
+
+// Operator adds REGEXP and renders IS DISTINCT FROM with the null safe <=> operator
+func (MySQLDialect) Operator(op Op) (string, bool) {
+	return lookupOperator(mySQLOperators, op)
+}
+
+// MaxParams returns 65535, the limit of prepared statements
+func (MySQLDialect) MaxParams() int {
+	return 65535
+}
+
+// UpsertStyle returns UpsertOnDuplicateKey
+func (MySQLDialect) UpsertStyle() int {
+	return UpsertOnDuplicateKey
+}
+
+// MariaDBDialect is the dialect of MariaDB, MySQL with RETURNING for INSERT and DELETE.
+// Version is the MariaDB version in the form of MYSQL_VERSION_ID (100500 for 10.5.0), the zero value means the latest version
+type MariaDBDialect struct {
+	MySQLDialect
+	Version int
+}
+
+// Supports reports WITH RECURSIVE, parenthesized compound members, row values, JOIN USING, RIGHT and NATURAL JOIN
+// and INTERSECT/EXCEPT from MariaDBVersionIntersect. NULLS ordering is emulated
+func (d MariaDBDialect) Supports(feature int) bool {
+	switch feature {
+	case FeatureRecursiveKeyword, FeatureCompoundParentheses, FeatureRowValues, FeatureJoinUsing, FeatureRightJoin,
+		FeatureNaturalJoin:
+		return true
+	case FeatureIntersectExcept:
+		return d.Version == 0 || d.Version >= MariaDBVersionIntersect
+	default:
+		return false
+	}
+}
+
+// ReturningStyle returns ReturningClause, or ReturningNone before MariaDBVersionReturning
+func (d MariaDBDialect) ReturningStyle() int {
+	if d.Version > 0 && d.Version < MariaDBVersionReturning {
+		return ReturningNone
+	}
+
+	return ReturningClause
+}
+
+// PgSQLDialect is the dialect of PostgreSQL
+type PgSQLDialect struct {
+	BaseDialect
+}
+
+// Placeholder returns $1, $2...
+func (PgSQLDialect) Placeholder(position int) string {
+	return "$" + strconv.Itoa(position)
+}
+
+// Supports reports the ANSI features, materialized common table expressions, WITH in front of INSERT, UPDATE and DELETE and ILIKE
+func (d PgSQLDialect) Supports(feature int) bool {
+	switch feature {
+	case FeatureMaterializedCTE, FeatureWithInsert, FeatureWithUpdateDelete, FeatureILike:
+		return true
+	default:
+		return d.BaseDialect.Supports(feature)
+	}
+}
+
+// Operator adds ILIKE, the ~ regular expression match and the @>, <@ and && array and range operators
+func (PgSQLDialect) Operator(op Op) (string, bool) {
+	return lookupOperator(pgSQLOperators, op)
+}
+
+// MaxParams returns 65535, the limit of the wire protocol
+func (PgSQLDialect) MaxParams() int {
+	return 65535
+}
+
+// UpsertStyle returns UpsertOnConflict
+func (PgSQLDialect) UpsertStyle() int {
+	return UpsertOnConflict
+}
+
+// ReturningStyle returns ReturningClause
+func (PgSQLDialect) ReturningStyle() int {
+	return ReturningClause
+}
+
+// FirebirdDialect is the dialect of FirebirdSQL.
+// Version selects the pagination syntax: the zero value uses SELECT FIRST n SKIP m, which every Firebird version understands,
+// FirebirdVersion2 uses ROWS and FirebirdVersion3 (or later) uses OFFSET/FETCH. LATERAL needs FirebirdVersion4
+type FirebirdDialect struct {
+	BaseDialect
+	Version int
+}
+
+// Pagination renders FIRST/SKIP, ROWS or OFFSET/FETCH depending on the Version
+func (d FirebirdDialect) Pagination(limit, offset int) (prefix, suffix string) {
+	switch {
+	case d.Version >= FirebirdVersion3:
+		return "", offsetFetch(limit, offset)
+	case d.Version == FirebirdVersion2 && limit > 0:
+		return "", firebirdRows(limit, offset)
+	default:
+		return firstSkip(limit, offset), ""
+	}
+}
+
+// Supports reports NULLS ordering, WITH RECURSIVE, JOIN USING, RIGHT, FULL and NATURAL JOIN, returning the updated rows
+// and LATERAL from FirebirdVersion4
+func (d FirebirdDialect) Supports(feature int) bool {
+	switch feature {
+	case FeatureNullsOrdering, FeatureRecursiveKeyword, FeatureJoinUsing, FeatureRightJoin, FeatureFullJoin, FeatureNaturalJoin,
+		FeatureUpdateReturning:
+		return true
+	case FeatureLateral:
+		return d.Version >= FirebirdVersion4
+	default:
+		return false
+	}
+}
+
+// MultiRowStyle returns MultiRowUnionAll, Firebird has no multi-row VALUES
+func (FirebirdDialect) MultiRowStyle() int {
+	return MultiRowUnionAll
+}
+
+// MaxInsertRows returns 254, each SELECT of the UNION ALL is a context and Firebird allows 255 contexts in a statement
+func (FirebirdDialect) MaxInsertRows() int {
+	return 254
+}
+
+// DummyTable returns RDB$DATABASE
+func (FirebirdDialect) DummyTable() string {
+	return "RDB$DATABASE"
+}
+
+// UpsertStyle returns UpsertUpdateOrInsert
+func (FirebirdDialect) UpsertStyle() int {
+	return UpsertUpdateOrInsert
+}
+
+// ReturningStyle returns ReturningClause
+func (FirebirdDialect) ReturningStyle() int {
+	return ReturningClause
+}
+
+// MSSQLDialect is the dialect of Microsoft SQL Server
+type MSSQLDialect struct {
+	BaseDialect
+}
+
+// QuoteIdentifier wraps the name in square brackets, doubling the closing brackets within
+func (MSSQLDialect) QuoteIdentifier(name string) string {
+	return "[" + strings.ReplaceAll(name, "]", "]]") + "]"
+}
+
+// EscapeLike also escapes [, which opens a character range in SQL Server patterns
+func (MSSQLDialect) EscapeLike(value string) string {
+	return mSSQLLikeEscaper.Replace(value)
+}
+
+var mSSQLLikeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`, "[", `\[`)
+
+// Placeholder returns @p1, @p2...
+func (MSSQLDialect) Placeholder(position int) string {
+	return "@p" + strconv.Itoa(position)
+}
+
+// Pagination renders TOP n when there is no offset, OFFSET m ROWS FETCH NEXT n ROWS ONLY otherwise
+func (MSSQLDialect) Pagination(limit, offset int) (prefix, suffix string) {
+	if offset == 0 {
+		if limit > 0 {
+			return "TOP " + strconv.Itoa(limit), ""
+		}
+
+		return "", ""
+	}
+
+	return "", offsetFetch(limit, offset)
+}
+
+// PaginationOrderBy returns (SELECT NULL), as OFFSET/FETCH is only valid after ORDER BY
+func (MSSQLDialect) PaginationOrderBy() string {
+	return "(SELECT NULL)"
+}
+
+// Supports reports WITH in front of INSERT, UPDATE and DELETE, INTERSECT/EXCEPT, parenthesized compound members,
+// RIGHT and FULL JOIN, CROSS APPLY, the MERGE terminator and returning the updated rows. NULLS ordering and JOIN USING
+// are emulated and recursive common table expressions have no RECURSIVE keyword
+func (MSSQLDialect) Supports(feature int) bool {
+	switch feature {
+	case FeatureWithInsert, FeatureWithUpdateDelete, FeatureIntersectExcept, FeatureCompoundParentheses, FeatureRightJoin,
+		FeatureFullJoin, FeatureCrossApply, FeatureMergeTerminator, FeatureUpdateReturning:
+		return true
+	default:
+		return false
+	}
+}
+
+// Operator emulates IS DISTINCT FROM with INTERSECT, which compares NULLs as equal
+func (MSSQLDialect) Operator(op Op) (string, bool) {
+	return lookupOperator(mSSQLOperators, op)
+}
+
+// MaxParams returns 2100
+func (MSSQLDialect) MaxParams() int {
+	return 2100
+}
+
+// MaxInsertRows returns 1000, the limit of the VALUES row constructor
+func (MSSQLDialect) MaxInsertRows() int {
+	return 1000
+}
+
+// UpsertStyle returns UpsertMerge
+func (MSSQLDialect) UpsertStyle() int {
+	return UpsertMerge
+}
+
+// ReturningStyle returns ReturningOutput
+func (MSSQLDialect) ReturningStyle() int {
+	return ReturningOutput
+}
+
+// OracleDialect is the dialect of Oracle Database
+type OracleDialect struct {
+	BaseDialect
+}
+
+// Placeholder returns :1, :2...
+func (OracleDialect) Placeholder(position int) string {
+	return ":" + strconv.Itoa(position)
+}
+
+// ValidateIdentifier rejects identifiers Oracle cannot quote, the ones containing double quotes or NUL bytes
+func (d OracleDialect) ValidateIdentifier(name string) error {
+	if strings.Contains(name, "\"") {
+		return fmt.Errorf("%w: %q contains double quote", ErrInvalidIdentifier, name)
+	}
+
+	return d.BaseDialect.ValidateIdentifier(name)
+}
+
+// Pagination renders OFFSET m ROWS FETCH NEXT n ROWS ONLY
+func (OracleDialect) Pagination(limit, offset int) (prefix, suffix string) {
+	return "", offsetFetch(limit, offset)
+}
+
+// TableAlias renders table alias, Oracle does not accept AS for table aliases
+func (OracleDialect) TableAlias(table, alias string) string {
+	return table + " " + alias
+}
+
+// Supports reports NULLS ordering, INTERSECT/EXCEPT, parenthesized compound members, row values, JOIN USING,
+// RIGHT, FULL, NATURAL and LATERAL joins, CROSS APPLY and the WHERE of MERGE updates.
+// Recursive common table expressions have no RECURSIVE keyword
+func (OracleDialect) Supports(feature int) bool {
+	switch feature {
+	case FeatureNullsOrdering, FeatureIntersectExcept, FeatureCompoundParentheses, FeatureRowValues, FeatureJoinUsing,
+		FeatureRightJoin, FeatureFullJoin, FeatureNaturalJoin, FeatureLateral, FeatureCrossApply, FeatureMergeUpdateWhere:
+		return true
+	default:
+		return false
+	}
+}
+
+// Operator adds REGEXP_LIKE and emulates IS DISTINCT FROM with DECODE, which compares NULLs as equal
+func (OracleDialect) Operator(op Op) (string, bool) {
+	return lookupOperator(oracleOperators, op)
+}
+
+// MultiRowStyle returns MultiRowInsertAll, Oracle has no multi-row VALUES
+func (OracleDialect) MultiRowStyle() int {
+	return MultiRowInsertAll
+}
+
+// DummyTable returns DUAL
+func (OracleDialect) DummyTable() string {
+	return "DUAL"
+}
+
+// MaxParams returns 65535
+func (OracleDialect) MaxParams() int {
+	return 65535
+}
+
+// UpsertStyle returns UpsertMerge
+func (OracleDialect) UpsertStyle() int {
+	return UpsertMerge
+}
+
+func limitOffset(limit, offset int) string {
+	parts := make([]string, 0, 2)
+	if limit > 0 {
+		parts = append(parts, "LIMIT "+strconv.Itoa(limit))
+	}
+
+	if offset > 0 {
+		parts = append(parts, "OFFSET "+strconv.Itoa(offset))
+	}
+
+	return strings.Join(parts, " ")
+}
+
+func offsetFetch(limit, offset int) string {
+	parts := make([]string, 0, 2)
+	if offset > 0 {
+		parts = append(parts, "OFFSET "+strconv.Itoa(offset)+" ROWS")
+	}
+
+	if limit > 0 {
+		if offset > 0 {
+			parts = append(parts, "FETCH NEXT "+strconv.Itoa(limit)+" ROWS ONLY")
+		} else {
+			parts = append(parts, "FETCH FIRST "+strconv.Itoa(limit)+" ROWS ONLY")
+		}
+	}
+
+	return strings.Join(parts, " ")
+}
+
+func firstSkip(limit, offset int) string {
+	parts := make([]string, 0, 2)
+	if limit > 0 {
+		parts = append(parts, "FIRST "+strconv.Itoa(limit))
+	}
+
+	if offset > 0 {
+		parts = append(parts, "SKIP "+strconv.Itoa(offset))
+	}
+
+	return strings.Join(parts, " ")
+}
+
+func firebirdRows(limit, offset int) string {
+	if offset == 0 {
+		return "ROWS " + strconv.Itoa(limit)
+	}
+
+	return "ROWS " + strconv.Itoa(offset+1) + " TO " + strconv.Itoa(offset+limit)
+}
@@ -0,0 +1,802 @@
+package builder
+
+import "strconv"
+
+type testDialect struct {
+	BaseDialect
+}
+
+func (testDialect) QuoteIdentifier(name string) string {
+	return "<" + name + ">"
+}
+
+func (testDialect) Placeholder(position int) string {
+	return ":p" + strconv.Itoa(position)
+}
+
+func (t *TestSuite) TestCustomDialect() {
+	builder := New()
+	err := builder.SetDialect(testDialect{})
+	t.Nil(err)
+
+	sql, err := builder.
+		Select("table1").
+		Fields("field1").
+		Where("field1", "=", 5).
+		In("field2", 1, 2).
+		Limit(10).
+		Offset(20).
+		AsSQL()
+
+	t.Nil(err)
+	t.Equal(sql, "SELECT <field1> FROM <table1> WHERE <field1>=:p1 AND <field2> IN (:p2,:p3) LIMIT 10 OFFSET 20")
+
+	whereParams := builder.GetParams()
+	t.Len(whereParams, 3)
+}
+
+func (t *TestSuite) TestSetInvalidDialect() {
+	builder := New()
+	t.ErrorIs(builder.SetDialect(nil), ErrInvalidDialect)
+	t.ErrorIs(builder.SetSQLFlavour(99), ErrInvalidSQLFlavour)
+}
+
+func (t *TestSuite) TestSqLiteFlavour() {
+	builder := New()
+	t.Nil(builder.SetSQLFlavour(FlavourSqLite))
+
+	sql, err := builder.
+		Select("table1").
+		Where("field1", "=", 5).
+		Limit(10).
+		AsSQL()
+
+	t.Nil(err)
+	t.Equal(sql, "SELECT * FROM \"table1\" WHERE \"field1\"=? LIMIT 10")
+}
+
+func (t *TestSuite) TestFirebirdPagination() {
+	tests := []struct {
+		version       int
+		limit, offset int
+		expected      string
+	}{
+		{0, 10, 20, "SELECT FIRST 10 SKIP 20 \"field1\" FROM \"table1\" WHERE \"field1\"=?"},
+		{0, 10, 0, "SELECT FIRST 10 \"field1\" FROM \"table1\" WHERE \"field1\"=?"},
+		{0, 0, 20, "SELECT SKIP 20 \"field1\" FROM \"table1\" WHERE \"field1\"=?"},
+		{FirebirdVersion2, 10, 20, "SELECT \"field1\" FROM \"table1\" WHERE \"field1\"=? ROWS 21 TO 30"},
+		{FirebirdVersion2, 10, 0, "SELECT \"field1\" FROM \"table1\" WHERE \"field1\"=? ROWS 10"},
+		{FirebirdVersion2, 0, 20, "SELECT SKIP 20 \"field1\" FROM \"table1\" WHERE \"field1\"=?"},
+		{FirebirdVersion3, 10, 20, "SELECT \"field1\" FROM \"table1\" WHERE \"field1\"=? OFFSET 20 ROWS FETCH NEXT 10 ROWS ONLY"},
+		{FirebirdVersion3, 10, 0, "SELECT \"field1\" FROM \"table1\" WHERE \"field1\"=? FETCH FIRST 10 ROWS ONLY"},
+		{FirebirdVersion3, 0, 20, "SELECT \"field1\" FROM \"table1\" WHERE \"field1\"=? OFFSET 20 ROWS"},
+	}
+
+	for _, test := range tests {
+		builder := New()
+		t.Nil(builder.SetDialect(FirebirdDialect{Version: test.version}))
+
+		sql, err := builder.
+			Select("table1").
+			Fields("field1").
+			Where("field1", "=", 5).
+			Limit(test.limit).
+			Offset(test.offset).
+			AsSQL()
+
+		t.Nil(err)
+		t.Equal(test.expected, sql)
+	}
+}
+
+func (t *TestSuite) TestFirebirdFlavour() {
+	builder := New()
+	t.Nil(builder.SetSQLFlavour(FlavourFirebirdSQL))
+
+	sql, err := builder.
+		Select("table1").
+		Limit(10).
+		Offset(100).
+		AsSQL()
+
+	t.Nil(err)
+	t.Equal(sql, "SELECT FIRST 10 SKIP 100 * FROM \"table1\"")
+}
+
+func (t *TestSuite) TestMSSQLFlavour() {
+	builder := New()
+	t.Nil(builder.SetSQLFlavour(FlavourMSSQL))
+
+	sql, err := builder.
+		Select("table1").
+		Fields("field1", "field2").
+		Where("field1", "=", 5).
+		In("field2", 1, 2).
+		Limit(10).
+		AsSQL()
+
+	t.Nil(err)
+	t.Equal(sql, "SELECT TOP 10 [field1],[field2] FROM [table1] WHERE [field1]=@p1 AND [field2] IN (@p2,@p3)")
+
+	whereParams := builder.GetParams()
+	t.Len(whereParams, 3)
+}
+
+func (t *TestSuite) TestMSSQLOffsetPagination() {
+	builder := New()
+	t.Nil(builder.SetSQLFlavour(FlavourMSSQL))
+
+	sql, err := builder.
+		Select("table1").
+		Limit(10).
+		Offset(20).
+		AsSQL()
+
+	t.Nil(err)
+	t.Equal(sql, "SELECT * FROM [table1] ORDER BY (SELECT NULL) OFFSET 20 ROWS FETCH NEXT 10 ROWS ONLY")
+
+	sql, err = builder.
+		Select("table1").
+		OrderBy("field1").
+		Offset(20).
+		AsSQL()
+
+	t.Nil(err)
+	t.Equal(sql, "SELECT * FROM [table1] ORDER BY [field1] OFFSET 20 ROWS")
+}
+
+func (t *TestSuite) TestOracleFlavour() {
+	builder := New()
+	t.Nil(builder.SetSQLFlavour(FlavourOracle))
+
+	sql, err := builder.
+		Select("table1").
+		Where("field1", "=", 5).
+		Between("field2", 1, 2).
+		Limit(10).
+		Offset(20).
+		AsSQL()
+
+	t.Nil(err)
+	t.Equal(sql, "SELECT * FROM \"table1\" WHERE \"field1\"=:1 AND \"field2\" BETWEEN :2 AND :3  OFFSET 20 ROWS FETCH NEXT 10 ROWS ONLY")
+
+	whereParams := builder.GetParams()
+	t.Len(whereParams, 3)
+}
+
+func (t *TestSuite) TestOracleFlavourWithInsert() {
+	builder := New()
+	t.Nil(builder.SetSQLFlavour(FlavourOracle))
+
+	sql, err := builder.
+		Insert("users").
+		Fields("name", "email").
+		Values("name", "email").
+		AsSQL()
+
+	t.Nil(err)
+	t.Equal(sql, "INSERT INTO \"users\" (\"name\",\"email\") VALUES (:1,:2)")
+}
+
+func (t *TestSuite) TestIdentifierEscaping() {
+	tests := []struct {
+		flavour  int
+		expected string
+	}{
+		{FlavourMySQL, "SELECT `fie``ld` FROM `ta\"ble` WHERE `a]b`=?"},
+		{FlavourPgSQL, "SELECT \"fie`ld\" FROM \"ta\"\"ble\" WHERE \"a]b\"=$1"},
+		{FlavourMSSQL, "SELECT [fie`ld] FROM [ta\"ble] WHERE [a]]b]=@p1"},
+	}
+
+	for _, test := range tests {
+		builder := New()
+		t.Nil(builder.SetSQLFlavour(test.flavour))
+
+		sql, err := builder.
+			Select("ta\"ble").
+			Fields("fie`ld").
+			Where("a]b", "=", 1).
+			AsSQL()
+
+		t.Nil(err)
+		t.Equal(test.expected, sql)
+	}
+}
+
+func (t *TestSuite) TestInvalidIdentifier() {
+	builder := New()
+	_, err := builder.
+		Select("table1").
+		Fields("field\x001").
+		AsSQL()
+
+	t.ErrorIs(err, ErrInvalidIdentifier)
+
+	_, err = builder.
+		Select("schema..table1").
+		AsSQL()
+
+	t.ErrorIs(err, ErrInvalidIdentifier)
+
+	t.Nil(builder.SetSQLFlavour(FlavourOracle))
+	_, err = builder.
+		Select("ta\"ble").
+		AsSQL()
+
+	t.ErrorIs(err, ErrInvalidIdentifier)
+
+	sql, err := builder.
+		Select("table1").
+		AsSQL()
+
+	t.Nil(err)
+	t.Equal(sql, "SELECT * FROM \"table1\"")
+}
+
+func (t *TestSuite) TestOracleAliases() {
+	builder := New()
+	t.Nil(builder.SetSQLFlavour(FlavourOracle))
+
+	sql, err := builder.
+		SelectAs("users", "u").
+		FieldAs("u.name", "user_name").
+		JoinAs("orders", "o", "o.user_id", "u.id", func(w Where) {
+			w.Where("o.status", "=", "paid")
+		}).
+		AsSQL()
+
+	t.Nil(err)
+	t.Equal(sql, "SELECT \"u\".\"name\" AS \"user_name\" FROM \"users\" \"u\" JOIN \"orders\" \"o\" ON \"o\".\"user_id\"=\"u\".\"id\" AND \"o\".\"status\"=:1")
+}
+
+func (t *TestSuite) TestOperators() {
+	tests := []struct {
+		flavour  int
+		expected string
+	}{
+		{FlavourMySQL, "SELECT * FROM `users` WHERE `name` REGEXP ? AND NOT (`nick` <=> ?) OR `a`!=?"},
+		{FlavourPgSQL, "SELECT * FROM \"users\" WHERE \"name\" ~ $1 AND \"nick\" IS DISTINCT FROM $2 OR \"a\"!=$3"},
+		{FlavourSqLite, "SELECT * FROM \"users\" WHERE \"name\" REGEXP ? AND \"nick\" IS NOT ? OR \"a\"!=?"},
+		{FlavourOracle, "SELECT * FROM \"users\" WHERE REGEXP_LIKE(\"name\", :1) AND DECODE(\"nick\", :2, 0, 1)=1 OR \"a\"!=:3"},
+	}
+
+	for _, test := range tests {
+		builder := New()
+		t.Nil(builder.SetSQLFlavour(test.flavour))
+
+		sql, err := builder.
+			Select("users").
+			WhereOp("name", Regexp, "^J").
+			WhereOp("nick", IsDistinctFrom, nil).
+			OrWhere("a", "!=", 1).
+			AsSQL()
+
+		t.Nil(err)
+		t.Equal(test.expected, sql)
+	}
+}
+
+func (t *TestSuite) TestDialectOperators() {
+	builder := New()
+	t.Nil(builder.SetSQLFlavour(FlavourPgSQL))
+
+	sql, err := builder.
+		Select("posts").
+		WhereOp("tags", ArrayContains, "{go}").
+		OrWhereOp("period", Overlaps, "[1,5)").
+		AsSQL()
+
+	t.Nil(err)
+	t.Equal(sql, "SELECT * FROM \"posts\" WHERE \"tags\" @> $1 OR \"period\" && $2")
+
+	t.Nil(builder.SetSQLFlavour(FlavourMSSQL))
+	sql, err = builder.
+		Select("posts").
+		WhereOp("author", IsDistinctFrom, nil).
+		AsSQL()
+
+	t.Nil(err)
+	t.Equal(sql, "SELECT * FROM [posts] WHERE NOT EXISTS (SELECT [author] INTERSECT SELECT @p1)")
+
+	_, err = builder.
+		Select("posts").
+		WhereOp("tags", ArrayContains, "{go}").
+		AsSQL()
+
+	t.ErrorIs(err, ErrNotSupported)
+
+	_, err = builder.
+		Select("posts").
+		WhereOp("tags", Op("~~~"), 1).
+		AsSQL()
+
+	var relationErr *InvalidRelationError
+	t.ErrorAs(err, &relationErr)
+}
+
+func (t *TestSuite) TestJoinTypes() {
+	builder := New()
+	t.Nil(builder.SetSQLFlavour(FlavourPgSQL))
+
+	latest := New().
+		Select("orders").
+		Fields("total").
+		WhereColumn("orders.user_id", "=", "u.id").
+		Where("status", "=", "paid").
+		OrderByDesc("created_at").
+		Limit(1)
+
+	sql, err := builder.
+		SelectAs("users", "u").
+		FullJoin("profiles", "profiles.user_id", "u.id", func(w Where) {}).
+		CrossJoin("settings").
+		NaturalJoin("user_stats").
+		LateralJoin(latest, "last_order").
+		Where("u.active", "=", true).
+		AsSQL()
+
+	t.Nil(err)
+	t.Equal(sql, "SELECT * FROM \"users\" AS \"u\" FULL JOIN \"profiles\" ON \"profiles\".\"user_id\"=\"u\".\"id\" CROSS JOIN \"settings\" NATURAL JOIN \"user_stats\" CROSS JOIN LATERAL (SELECT \"total\" FROM \"orders\" WHERE \"orders\".\"user_id\"=\"u\".\"id\" AND \"status\"=$1 ORDER BY \"created_at\" DESC LIMIT 1) AS \"last_order\" WHERE \"u\".\"active\"=$2")
+
+	whereParams := builder.GetParams()
+	t.Equal(whereParams, []interface{}{"paid", true})
+
+	t.Nil(builder.SetSQLFlavour(FlavourMSSQL))
+	sql, err = builder.
+		SelectAs("users", "u").
+		LateralJoin(latest, "last_order").
+		AsSQL()
+
+	t.Nil(err)
+	t.Equal(sql, "SELECT * FROM [users] AS [u] CROSS APPLY (SELECT TOP 1 [total] FROM [orders] WHERE [orders].[user_id]=[u].[id] AND [status]=@p1 ORDER BY [created_at] DESC) AS [last_order]")
+}
+
+func (t *TestSuite) TestUnsupportedJoinTypes() {
+	tests := []struct {
+		dialect Dialect
+		join    func(Builder) Builder
+	}{
+		{MySQLDialect{}, func(b Builder) Builder { return b.FullJoin("t2", "t2.id", "t1.id", func(w Where) {}) }},
+		{MySQLDialect{Version: 80013}, func(b Builder) Builder { return b.LateralJoin(New().Select("t2"), "l") }},
+		{SQLiteDialect{Version: 3038000}, func(b Builder) Builder { return b.RightJoin("t2", "t2.id", "t1.id", func(w Where) {}) }},
+		{SQLiteDialect{}, func(b Builder) Builder { return b.LateralJoin(New().Select("t2"), "l") }},
+		{MSSQLDialect{}, func(b Builder) Builder { return b.NaturalJoin("t2") }},
+		{FirebirdDialect{Version: FirebirdVersion3}, func(b Builder) Builder { return b.LateralJoin(New().Select("t2"), "l") }},
+	}
+
+	for _, test := range tests {
+		builder := New()
+		t.Nil(builder.SetDialect(test.dialect))
+
+		_, err := test.join(builder.Select("t1")).AsSQL()
+		t.ErrorIs(err, ErrNotSupported)
+	}
+
+	builder := New()
+	t.Nil(builder.SetDialect(SQLiteDialect{Version: SQLiteVersionFullJoin}))
+	sql, err := builder.
+		Select("t1").
+		FullJoin("t2", "t2.id", "t1.id", func(w Where) {}).
+		AsSQL()
+
+	t.Nil(err)
+	t.Equal(sql, "SELECT * FROM \"t1\" FULL JOIN \"t2\" ON \"t2\".\"id\"=\"t1\".\"id\"")
+}
+
+func (t *TestSuite) TestMultiRowInsertStyles() {
+	tests := []struct {
+		flavour  int
+		expected string
+	}{
+		{FlavourPgSQL, "INSERT INTO \"users\" (\"name\",\"age\") VALUES ($1,$2),($3,$4)"},
+		{FlavourMSSQL, "INSERT INTO [users] ([name],[age]) VALUES (@p1,@p2),(@p3,@p4)"},
+		{FlavourOracle, "INSERT ALL INTO \"users\" (\"name\",\"age\") VALUES (:1,:2) INTO \"users\" (\"name\",\"age\") VALUES (:3,:4) SELECT 1 FROM DUAL"},
+		{FlavourFirebirdSQL, "INSERT INTO \"users\" (\"name\",\"age\") SELECT ?,? FROM RDB$DATABASE UNION ALL SELECT ?,? FROM RDB$DATABASE"},
+	}
+
+	for _, test := range tests {
+		builder := New()
+		t.Nil(builder.SetSQLFlavour(test.flavour))
+
+		sql, err := builder.
+			Insert("users").
+			Fields("name", "age").
+			AddRow("John", 30).
+			AddRow("Jane", 25).
+			AsSQL()
+
+		t.Nil(err)
+		t.Equal(test.expected, sql)
+		t.Equal([]interface{}{"John", 30, "Jane", 25}, builder.GetParams())
+	}
+}
+
+func (t *TestSuite) TestUpsert() {
+	tests := []struct {
+		dialect  Dialect
+		expected string
+	}{
+		{PgSQLDialect{}, "INSERT INTO \"users\" (\"id\",\"name\",\"email\") VALUES ($1,$2,$3) ON CONFLICT (\"id\") DO UPDATE SET \"name\"=EXCLUDED.\"name\",\"email\"=EXCLUDED.\"email\" WHERE \"users\".\"locked\"=$4"},
+		{SQLiteDialect{}, "INSERT INTO \"users\" (\"id\",\"name\",\"email\") VALUES (?,?,?) ON CONFLICT (\"id\") DO UPDATE SET \"name\"=EXCLUDED.\"name\",\"email\"=EXCLUDED.\"email\" WHERE \"users\".\"locked\"=?"},
+		{MSSQLDialect{}, "MERGE INTO [users] USING (VALUES (@p1,@p2,@p3)) AS [excluded] ([id],[name],[email]) ON ([users].[id]=[excluded].[id]) WHEN MATCHED AND ([users].[locked]=@p4) THEN UPDATE SET [name]=[excluded].[name],[email]=[excluded].[email] WHEN NOT MATCHED THEN INSERT ([id],[name],[email]) VALUES ([excluded].[id],[excluded].[name],[excluded].[email]);"},
+		{OracleDialect{}, "MERGE INTO \"users\" USING (SELECT :1 AS \"id\",:2 AS \"name\",:3 AS \"email\" FROM DUAL) \"excluded\" ON (\"users\".\"id\"=\"excluded\".\"id\") WHEN MATCHED THEN UPDATE SET \"name\"=\"excluded\".\"name\",\"email\"=\"excluded\".\"email\" WHERE \"users\".\"locked\"=:4 WHEN NOT MATCHED THEN INSERT (\"id\",\"name\",\"email\") VALUES (\"excluded\".\"id\",\"excluded\".\"name\",\"excluded\".\"email\")"},
+	}
+
+	for _, test := range tests {
+		builder := New()
+		t.Nil(builder.SetDialect(test.dialect))
+
+		sql, err := builder.
+			Insert("users").
+			Fields("id", "name", "email").
+			Values(1, "John", "john@example.com").
+			OnConflict("id").
+			DoUpdate().
+			Where("users.locked", "=", false).
+			AsSQL()
+
+		t.Nil(err)
+		t.Equal(test.expected, sql)
+		t.Equal([]interface{}{1, "John", "john@example.com", false}, builder.GetParams())
+	}
+}
+
+func (t *TestSuite) TestUpsertMergeWhere() {
+	tests := []struct {
+		dialect  Dialect
+		expected string
+	}{
+		{MSSQLDialect{}, "MERGE INTO [users] USING (VALUES (@p1,@p2)) AS [excluded] ([id],[name]) ON ([users].[id]=[excluded].[id]) WHEN MATCHED AND ([users].[locked]=@p3 AND [users].[name]<>[excluded].[name]) THEN UPDATE SET [name]=[excluded].[name] WHEN NOT MATCHED THEN INSERT ([id],[name]) VALUES ([excluded].[id],[excluded].[name]);"},
+		{OracleDialect{}, "MERGE INTO \"users\" USING (SELECT :1 AS \"id\",:2 AS \"name\" FROM DUAL) \"excluded\" ON (\"users\".\"id\"=\"excluded\".\"id\") WHEN MATCHED THEN UPDATE SET \"name\"=\"excluded\".\"name\" WHERE \"users\".\"locked\"=:3 AND \"users\".\"name\"<>\"excluded\".\"name\" WHEN NOT MATCHED THEN INSERT (\"id\",\"name\") VALUES (\"excluded\".\"id\",\"excluded\".\"name\")"},
+	}
+
+	for _, test := range tests {
+		builder := New()
+		t.Nil(builder.SetDialect(test.dialect))
+
+		sql, err := builder.
+			Insert("users").
+			Fields("id", "name").
+			Values(1, "John").
+			OnConflict("id").
+			DoUpdate().
+			Where("locked", "=", false).
+			WhereColumn("name", "<>", "excluded.name").
+			AsSQL()
+
+		t.Nil(err)
+		t.Equal(test.expected, sql)
+	}
+}
+
+func (t *TestSuite) TestUpsertMySQL() {
+	builder := New()
+	sql, err := builder.
+		Insert("users").
+		Fields("id", "name", "email").
+		Values(1, "John", "john@example.com").
+		OnConflict("id").
+		DoUpdate("name").
+		AsSQL()
+
+	t.Nil(err)
+	t.Equal(sql, "INSERT INTO `users` (`id`,`name`,`email`) VALUES (?,?,?) AS `new` ON DUPLICATE KEY UPDATE `name`=`new`.`name`")
+
+	t.Nil(builder.SetDialect(MySQLDialect{Version: 80019}))
+	sql, err = builder.
+		Insert("users").
+		Fields("id", "name").
+		AddRow(1, "John").
+		AddRow(2, "Jane").
+		OnConflict("id").
+		DoUpdate().
+		AsSQL()
+
+	t.Nil(err)
+	t.Equal(sql, "INSERT INTO `users` (`id`,`name`) VALUES (?,?),(?,?) ON DUPLICATE KEY UPDATE `name`=VALUES(`name`)")
+
+	sql, err = builder.
+		Insert("users").
+		Fields("id", "name").
+		Values(1, "John").
+		DoNothing().
+		AsSQL()
+
+	t.Nil(err)
+	t.Equal(sql, "INSERT INTO `users` (`id`,`name`) VALUES (?,?) ON DUPLICATE KEY UPDATE `id`=`id`")
+
+	_, err = builder.
+		Insert("users").
+		Fields("id", "name").
+		Values(1, "John").
+		DoUpdate("name").
+		Where("locked", "=", false).
+		AsSQL()
+
+	t.ErrorIs(err, ErrNotSupported)
+}
+
+func (t *TestSuite) TestUpsertDoNothing() {
+	builder := New()
+	t.Nil(builder.SetSQLFlavour(FlavourPgSQL))
+
+	sql, err := builder.
+		Insert("users").
+		Fields("id", "name").
+		AddRow(1, "John").
+		AddRow(2, "Jane").
+		OnConflict("id").
+		DoNothing().
+		AsSQL()
+
+	t.Nil(err)
+	t.Equal(sql, "INSERT INTO \"users\" (\"id\",\"name\") VALUES ($1,$2),($3,$4) ON CONFLICT (\"id\") DO NOTHING")
+
+	t.Nil(builder.SetSQLFlavour(FlavourMSSQL))
+	sql, err = builder.
+		Insert("users").
+		Fields("id", "name").
+		FromSelect(New().Select("staging").Fields("id", "name")).
+		OnConflict("id").
+		DoNothing().
+		AsSQL()
+
+	t.Nil(err)
+	t.Equal(sql, "MERGE INTO [users] USING (SELECT [id],[name] FROM [staging]) AS [excluded] ([id],[name]) ON ([users].[id]=[excluded].[id]) WHEN NOT MATCHED THEN INSERT ([id],[name]) VALUES ([excluded].[id],[excluded].[name]);")
+}
+
+func (t *TestSuite) TestUpsertWithoutTarget() {
+	builder := New()
+	t.Nil(builder.SetSQLFlavour(FlavourPgSQL))
+
+	_, err := builder.
+		Insert("users").
+		Fields("id", "name").
+		Values(1, "John").
+		OnConflict().
+		DoUpdate().
+		AsSQL()
+
+	t.ErrorIs(err, ErrNotSupported)
+
+	_, err = builder.
+		Insert("users").
+		Fields("id", "name").
+		Values(1, "John").
+		DoUpdate("name").
+		AsSQL()
+
+	t.ErrorIs(err, ErrNotSupported)
+
+	sql, err := builder.
+		Insert("users").
+		Fields("id", "name").
+		Values(1, "John").
+		DoNothing().
+		AsSQL()
+
+	t.Nil(err)
+	t.Equal(sql, "INSERT INTO \"users\" (\"id\",\"name\") VALUES ($1,$2) ON CONFLICT DO NOTHING")
+
+	t.Nil(builder.SetDialect(SQLiteDialect{}))
+	sql, err = builder.
+		Insert("users").
+		Fields("id", "name").
+		Values(1, "John").
+		DoUpdate("name").
+		AsSQL()
+
+	t.Nil(err)
+	t.Equal(sql, "INSERT INTO \"users\" (\"id\",\"name\") VALUES (?,?) ON CONFLICT DO UPDATE SET \"name\"=EXCLUDED.\"name\"")
+
+	t.Nil(builder.SetDialect(SQLiteDialect{Version: 3034000}))
+	_, err = builder.
+		Insert("users").
+		Fields("id", "name").
+		Values(1, "John").
+		DoUpdate("name").
+		AsSQL()
+
+	t.ErrorIs(err, ErrNotSupported)
+}
+
+func (t *TestSuite) TestUpsertFromSelect() {
+	builder := New()
+	t.Nil(builder.SetSQLFlavour(FlavourSqLite))
+
+	sql, err := builder.
+		Insert("live").
+		Fields("a", "b").
+		FromSelect(New().Select("staging").Fields("a", "b")).
+		OnConflict("a").
+		DoUpdate().
+		AsSQL()
+
+	t.Nil(err)
+	t.Equal(sql, "INSERT INTO \"live\" (\"a\",\"b\") SELECT * FROM (SELECT \"a\",\"b\" FROM \"staging\") WHERE true ON CONFLICT (\"a\") DO UPDATE SET \"b\"=EXCLUDED.\"b\"")
+
+	sql, err = builder.
+		Insert("live").
+		Fields("a", "b").
+		FromSelect(New().Select("staging").Fields("a", "b").Where("valid", "=", 1)).
+		OnConflict("a").
+		DoNothing().
+		AsSQL()
+
+	t.Nil(err)
+	t.Equal(sql, "INSERT INTO \"live\" (\"a\",\"b\") SELECT \"a\",\"b\" FROM \"staging\" WHERE \"valid\"=? ON CONFLICT (\"a\") DO NOTHING")
+
+	t.Nil(builder.SetSQLFlavour(FlavourPgSQL))
+	sql, err = builder.
+		Insert("live").
+		Fields("a", "b").
+		FromSelect(New().Select("staging").Fields("a", "b")).
+		OnConflict("a").
+		DoNothing().
+		AsSQL()
+
+	t.Nil(err)
+	t.Equal(sql, "INSERT INTO \"live\" (\"a\",\"b\") SELECT \"a\",\"b\" FROM \"staging\" ON CONFLICT (\"a\") DO NOTHING")
+}
+
+func (t *TestSuite) TestUpsertWithoutFields() {
+	for _, flavour := range []int{FlavourMySQL, FlavourPgSQL, FlavourMSSQL, FlavourFirebirdSQL} {
+		builder := New()
+		t.Nil(builder.SetSQLFlavour(flavour))
+
+		t.NotPanics(func() {
+			_, err := builder.
+				Insert("live").
+				FromSelect(New().Select("staging")).
+				OnConflict("a").
+				DoNothing().
+				AsSQL()
+			t.ErrorIs(err, errUpsertWithoutFields)
+
+			_, err = builder.
+				Insert("live").
+				FromSelect(New().Select("staging")).
+				OnConflict("a").
+				DoUpdate().
+				AsSQL()
+			t.ErrorIs(err, errUpsertWithoutFields)
+		})
+	}
+}
+
+func (t *TestSuite) TestUpsertFirebird() {
+	builder := New()
+	t.Nil(builder.SetSQLFlavour(FlavourFirebirdSQL))
+
+	sql, err := builder.
+		Insert("users").
+		Fields("id", "name").
+		Values(1, "John").
+		OnConflict("id").
+		DoUpdate().
+		AsSQL()
+
+	t.Nil(err)
+	t.Equal(sql, "UPDATE OR INSERT INTO \"users\" (\"id\",\"name\") VALUES (?,?) MATCHING (\"id\")")
+
+	unsupported := []func(Builder) Builder{
+		func(b Builder) Builder { return b.Values(1, "John").DoNothing() },
+		func(b Builder) Builder { return b.Values(1, "John").DoUpdate().Where("locked", "=", false) },
+		func(b Builder) Builder { return b.AddRow(1, "John").AddRow(2, "Jane").DoUpdate() },
+		func(b Builder) Builder {
+			return b.Fields("id", "name", "email").Values(1, "John", "x").DoUpdate("name")
+		},
+	}
+
+	for _, upsert := range unsupported {
+		_, err = upsert(builder.Insert("users").Fields("id", "name").OnConflict("id")).AsSQL()
+		t.ErrorIs(err, ErrNotSupported)
+	}
+}
+
+func (t *TestSuite) TestReturning() {
+	tests := []struct {
+		flavour                int
+		insert, update, delete string
+	}{
+		{
+			FlavourPgSQL,
+			"INSERT INTO \"users\" (\"name\") VALUES ($1) RETURNING \"id\",\"created_at\"",
+			"UPDATE \"users\" SET \"name\"=$1 WHERE \"id\"=$2 RETURNING \"id\",\"created_at\"",
+			"DELETE FROM \"users\" WHERE \"id\"=$1 RETURNING \"id\",\"created_at\"",
+		},
+		{
+			FlavourMSSQL,
+			"INSERT INTO [users] ([name]) OUTPUT INSERTED.[id],INSERTED.[created_at] VALUES (@p1)",
+			"UPDATE [users] SET [name]=@p1 OUTPUT INSERTED.[id],INSERTED.[created_at] WHERE [id]=@p2",
+			"DELETE FROM [users] OUTPUT DELETED.[id],DELETED.[created_at] WHERE [id]=@p1",
+		},
+		{
+			FlavourFirebirdSQL,
+			"INSERT INTO \"users\" (\"name\") VALUES (?) RETURNING \"id\",\"created_at\"",
+			"UPDATE \"users\" SET \"name\"=? WHERE \"id\"=? RETURNING \"id\",\"created_at\"",
+			"DELETE FROM \"users\" WHERE \"id\"=? RETURNING \"id\",\"created_at\"",
+		},
+	}
+
+	for _, test := range tests {
+		builder := New()
+		t.Nil(builder.SetSQLFlavour(test.flavour))
+
+		sql, err := builder.Insert("users").Fields("name").Values("John").Returning("id", "created_at").AsSQL()
+		t.Nil(err)
+		t.Equal(test.insert, sql)
+
+		sql, err = builder.Update("users").Fields("name").Values("John").Where("id", "=", 1).Returning("id", "created_at").AsSQL()
+		t.Nil(err)
+		t.Equal(test.update, sql)
+
+		sql, err = builder.Delete("users").Where("id", "=", 1).Returning("id", "created_at").AsSQL()
+		t.Nil(err)
+		t.Equal(test.delete, sql)
+	}
+}
+
+func (t *TestSuite) TestUnsupportedReturning() {
+	builder := New()
+	_, err := builder.Insert("users").Fields("name").Values("John").Returning("id").AsSQL()
+	t.ErrorIs(err, ErrNotSupported)
+
+	t.Nil(builder.SetSQLFlavour(FlavourOracle))
+	_, err = builder.Delete("users").Returning("id").AsSQL()
+	t.ErrorIs(err, ErrNotSupported)
+
+	t.Nil(builder.SetDialect(SQLiteDialect{Version: 3034000}))
+	_, err = builder.Delete("users").Returning("id").AsSQL()
+	t.ErrorIs(err, ErrNotSupported)
+
+	t.Nil(builder.SetSQLFlavour(FlavourMariaDB))
+	sql, err := builder.Insert("users").Fields("name").Values("John").Returning("id").AsSQL()
+	t.Nil(err)
+	t.Equal(sql, "INSERT INTO `users` (`name`) VALUES (?) RETURNING `id`")
+
+	_, err = builder.Update("users").Fields("name").Values("John").Returning("id").AsSQL()
+	t.ErrorIs(err, ErrNotSupported)
+
+	t.Nil(builder.SetDialect(MariaDBDialect{Version: 100400}))
+	_, err = builder.Insert("users").Fields("name").Values("John").Returning("id").AsSQL()
+	t.ErrorIs(err, ErrNotSupported)
+
+	t.Nil(builder.SetDialect(MariaDBDialect{Version: MariaDBVersionReturning}))
+	_, err = builder.Insert("users").Fields("name").Values("John").Returning("id").AsSQL()
+	t.Nil(err)
+
+	t.Nil(builder.SetSQLFlavour(FlavourFirebirdSQL))
+	_, err = builder.Insert("users").Fields("name").AddRow("John").AddRow("Jane").Returning("id").AsSQL()
+	t.ErrorIs(err, ErrNotSupported)
+}
+
+func (t *TestSuite) TestUpsertReturning() {
+	builder := New()
+	t.Nil(builder.SetSQLFlavour(FlavourMSSQL))
+
+	sql, err := builder.
+		Insert("users").
+		Fields("id", "name").
+		Values(1, "John").
+		OnConflict("id").
+		DoUpdate().
+		Returning("id").
+		AsSQL()
+
+	t.Nil(err)
+	t.Equal(sql, "MERGE INTO [users] USING (VALUES (@p1,@p2)) AS [excluded] ([id],[name]) ON ([users].[id]=[excluded].[id]) WHEN MATCHED THEN UPDATE SET [name]=[excluded].[name] WHEN NOT MATCHED THEN INSERT ([id],[name]) VALUES ([excluded].[id],[excluded].[name]) OUTPUT INSERTED.[id];")
+
+	t.Nil(builder.SetSQLFlavour(FlavourPgSQL))
+	sql, err = builder.
+		Insert("users").
+		Fields("id", "name").
+		Values(1, "John").
+		OnConflict("id").
+		DoNothing().
+		Returning("id").
+		AsSQL()
+
+	t.Nil(err)
+	t.Equal(sql, "INSERT INTO \"users\" (\"id\",\"name\") VALUES ($1,$2) ON CONFLICT (\"id\") DO NOTHING RETURNING \"id\"")
+}
@@ -0,0 +1,41 @@
+package builder
+
+const (
+	tokenHaving = "HAVING"
+)
+
+// Having creates SQL HAVING block, for filtering on grouped results
+func (b *Build) Having(field, relation string, value interface{}) Builder {
+	b.having.AppendItem(
+		NewWhere(false, typeAnd, field, relation, value),
+	)
+
+	return b
+}
+
+// OrHaving creates SQL HAVING block preceded by OR operator
+func (b *Build) OrHaving(field, relation string, value interface{}) Builder {
+	b.having.AppendItem(
+		NewWhere(false, typeOr, field, relation, value),
+	)
+
+	return b
+}
+
+// HavingRaw creates SQL HAVING block where the field is not quoted, so aggregate functions can be used, like count(*)
+func (b *Build) HavingRaw(field, relation string, value interface{}) Builder {
+	b.having.AppendItem(
+		NewWhere(true, typeAnd, field, relation, value),
+	)
+
+	return b
+}
+
+// HavingGroup creates a new groups of HAVING, like HAVING `field` = ? and (`field2` = ?....). Provide the conditions in the closure where you get a Where builder
+func (b *Build) HavingGroup(fn WhereGroupFunc) Builder {
+	where := NewWhereGroup(typeAnd)
+	b.having.AppendItem(where)
+	fn(where)
+
+	return b
+}
@@ -8,11 +8,21 @@ func builderConcat(strBuilder *strings.Builder, pars ...string) {
 	}
 }
 
+// escapeLike escapes the LIKE wildcards and the escape character itself with backslash
+func escapeLike(value string) string {
+	return likeEscaper.Replace(value)
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// likePattern is the value of Contains, StartsWith and EndsWith, escaped by the dialect when the params are collected
+type likePattern struct {
+	prefix string
+	value  string
+	suffix string
+}
+
+// validateRelation reports if the relation is a registered operator, see RegisterOperator
 func validateRelation(relation string) bool {
-	switch relation {
-	case "=", ">", "<", ">=", "<=", "<>", "!=":
-		return true
-	default:
-		return false
-	}
+	return registeredOperators[Op(relation)]
 }
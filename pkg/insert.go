@@ -7,7 +7,8 @@ import (
 )
 
 var (
-	errFieldCountMismatch = errors.New("select filed and value count does not match")
+	errFieldCountMismatch  = errors.New("select filed and value count does not match")
+	errUpsertWithoutFields = errors.New("upsert needs the inserted Fields")
 )
 
 // Insert initiates a new INSERT INTO SQL
@@ -20,15 +21,13 @@ func (b *Build) Insert(tableName string) Builder {
 
 // Fields add fields for insert into or other SQL types
 func (b *Build) Fields(fields ...string) Builder {
-	b.fieldsAreRaw = false
-	b.fields = fields
+	b.fields = newColumns(fields, false)
 	return b
 }
 
-// Fields add fields for insert into or other SQL types
+// RawFields add fields for insert into or other SQL types without quoting them, so functions can be used, like count(*)
 func (b *Build) RawFields(fields ...string) Builder {
-	b.fieldsAreRaw = true
-	b.fields = fields
+	b.fields = newColumns(fields, true)
 	return b
 }
 
@@ -38,33 +37,202 @@ func (b *Build) Values(values ...interface{}) Builder {
 	return b
 }
 
+// Rows sets the rows of a multi-row insert, each row holds the binding values for Fields
+func (b *Build) Rows(rows ...[]interface{}) Builder {
+	b.rows = rows
+	return b
+}
+
+// AddRow adds a row to a multi-row insert, holding the binding values for Fields
+func (b *Build) AddRow(values ...interface{}) Builder {
+	b.rows = append(b.rows, values)
+	return b
+}
+
+// FromSelect inserts the rows of a select, like INSERT INTO `table` (`a`,`b`) SELECT `a`,`b` FROM `staging`.
+// The Fields of the insert are optional, the parameters of the select follow the ones of the insert
+func (b *Build) FromSelect(sub Builder) Builder {
+	b.insertFrom = sub
+	return b
+}
+
+// getInsertRows returns the rows set by Rows and AddRow, or the single row of Values
+func (b *Build) getInsertRows() [][]interface{} {
+	if len(b.rows) > 0 {
+		return b.rows
+	}
+
+	return [][]interface{}{b.values}
+}
+
+func (b *Build) getInsertRowParams() []interface{} {
+	if b.insertFrom != nil {
+		return b.getSubParams(b.insertFrom)
+	}
+
+	var pars []interface{}
+	for _, row := range b.getInsertRows() {
+		pars = append(pars, row...)
+	}
+
+	return pars
+}
+
+// getInsertParams returns the params of the inserted rows followed by the ones of the conflict handling
+func (b *Build) getInsertParams() []interface{} {
+	pars := b.getInsertRowParams()
+	if b.upsert != nil {
+		pars = append(pars, b.getWhereParams(b.where)...)
+	}
+
+	return pars
+}
+
 func (b *Build) generateInsertSQL() (string, error) {
-	valueCount := len(b.values)
+	if err := b.checkColumnParams(); err != nil {
+		return "", err
+	}
+
+	if b.upsert != nil {
+		if len(b.fields) == 0 {
+			return "", errUpsertWithoutFields
+		}
+
+		switch b.dialect.UpsertStyle() {
+		case UpsertUpdateOrInsert:
+			return b.generateUpdateOrInsertSQL()
+		case UpsertMerge:
+			return b.generateMergeSQL()
+		case UpsertNone:
+			return "", fmt.Errorf("%w: upsert", ErrNotSupported)
+		}
+	}
 
-	if len(b.fields) != valueCount {
-		return "", errFieldCountMismatch
+	if b.insertFrom != nil {
+		return b.generateInsertSelectSQL() + b.generateUpsertClause() + b.generateReturning(), nil
 	}
 
-	if valueCount == 0 {
-		return "", fmt.Errorf("at least one field need to be inserted")
+	sql, err := b.generateInsertValuesSQL()
+	if err != nil {
+		return "", err
 	}
 
+	return sql + b.generateUpsertClause() + b.generateReturning(), nil
+}
+
+// validateInsertRows checks that every row has a value for each field
+func (b *Build) validateInsertRows() error {
+	for _, row := range b.getInsertRows() {
+		if len(b.fields) != len(row) {
+			return errFieldCountMismatch
+		}
+	}
+
+	if len(b.fields) == 0 {
+		return fmt.Errorf("at least one field need to be inserted")
+	}
+
+	return nil
+}
+
+func (b *Build) generateInsertValuesSQL() (string, error) {
+	if err := b.validateInsertRows(); err != nil {
+		return "", err
+	}
+
+	rows := b.getInsertRows()
+	into := b.quote(b.tableName) + " (" + b.getColumnNames() + ")"
 	builder := &strings.Builder{}
+	if len(rows) > 1 && b.dialect.MultiRowStyle() == MultiRowInsertAll {
+		if len(b.returning) > 0 {
+			return "", fmt.Errorf("%w: RETURNING in INSERT ALL", ErrNotSupported)
+		}
+
+		builder.WriteString("INSERT ALL")
+		for _, row := range rows {
+			builderConcat(builder, " INTO ", into, " VALUES (", b.getValuePlaceholders(len(row)), ")")
+		}
+		builderConcat(builder, " SELECT 1", b.getDummyFrom())
 
-	builderConcat(
-		builder,
-		"INSERT INTO ", b.fieldQuote, b.tableName, b.fieldQuote,
-		" (", b.getSelectFields(), ")",
-		" VALUES (",
-	)
+		return builder.String(), nil
+	}
+
+	builderConcat(builder, "INSERT INTO ", into, b.generateOutput(outputInserted))
+	if len(rows) > 1 && b.dialect.MultiRowStyle() == MultiRowUnionAll {
+		if len(b.returning) > 0 {
+			return "", fmt.Errorf("%w: RETURNING in multi-row INSERT ... SELECT", ErrNotSupported)
+		}
+
+		for i, row := range rows {
+			if i > 0 {
+				builder.WriteString(" UNION ALL")
+			}
+			builderConcat(builder, " SELECT ", b.getValuePlaceholders(len(row)), b.getDummyFrom())
+		}
 
-	for i := 0; i < valueCount; i++ {
+		return builder.String(), nil
+	}
+
+	builder.WriteString(" VALUES ")
+	for i, row := range rows {
 		if i > 0 {
 			builder.WriteString(",")
 		}
-		builder.WriteString(b.getBindingParameter())
+		builderConcat(builder, "(", b.getValuePlaceholders(len(row)), ")")
 	}
-	builder.WriteString(")")
 
 	return builder.String(), nil
 }
+
+func (b *Build) generateInsertSelectSQL() string {
+	builder := &strings.Builder{}
+	builderConcat(builder, "INSERT INTO ", b.quote(b.tableName))
+	if len(b.fields) > 0 {
+		builderConcat(builder, " (", b.getColumnNames(), ")")
+	}
+	builder.WriteString(b.generateOutput(outputInserted))
+	builder.WriteString(" ")
+	if b.embedsWith() {
+		builder.WriteString(b.generateWith())
+	}
+	builder.WriteString(b.generateInsertSelect())
+
+	return builder.String()
+}
+
+// generateInsertSelect renders the select of FromSelect. When an ON CONFLICT follows and the dialect would parse it as
+// a join constraint, a select without WHERE is wrapped as SELECT * FROM (...) WHERE true
+func (b *Build) generateInsertSelect() string {
+	sql := b.generateSubSQL(b.insertFrom)
+	if b.upsert == nil || b.dialect.UpsertStyle() != UpsertOnConflict || !b.dialect.Supports(FeatureUpsertSelectWhere) {
+		return sql
+	}
+
+	if s, ok := b.insertFrom.(*Build); ok && len(s.where.GetItems()) > 0 && len(s.compounds) == 0 {
+		return sql
+	}
+
+	return "SELECT * FROM (" + sql + ") WHERE true"
+}
+
+// getValuePlaceholders renders count binding parameters separated by commas
+func (b *Build) getValuePlaceholders(count int) string {
+	builder := &strings.Builder{}
+	for i := 0; i < count; i++ {
+		if i > 0 {
+			builder.WriteString(",")
+		}
+		builder.WriteString(b.getBindingParameter())
+	}
+
+	return builder.String()
+}
+
+// getDummyFrom renders FROM with the dummy table of the dialect, if it needs one
+func (b *Build) getDummyFrom() string {
+	if table := b.dialect.DummyTable(); table != "" {
+		return " FROM " + table
+	}
+
+	return ""
+}
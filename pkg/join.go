@@ -1,43 +1,139 @@
 package builder
 
 import (
+	"errors"
+	"fmt"
 	"strings"
 )
 
+var (
+	ErrEmptyJoinCondition = errors.New("join without ON condition")
+)
+
 // Join is the structure of a JOINS SQL clause
 type Join struct {
 	joinType  string
 	tableName string
+	sub       Builder
+	alias     string
 	leftCond  string
 	rightCond string
+	using     []string
 	where     Where
 }
 
-// Join creates a table join clause, like JOIN `table1` ON `table1.id` = `table2.table1_id`
+// On is the condition builder of JoinOn, the conditions of the closure are the whole ON clause
+type On = Where
+
+// OnFunc is the closure of JoinOn, providing the ON condition builder
+type OnFunc func(on On)
+
+// Join creates a table join clause, like JOIN `table1` ON `table1.id` = `table2.table1_id`.
+// When leftCond and rightCond are empty, the conditions of the closure are the whole ON clause
 func (b *Build) Join(tableName, leftCond, rightCond string, fn WhereGroupFunc) Builder {
-	return b.getJoinBuilder(joinTypeInner, tableName, leftCond, rightCond, fn)
+	return b.getJoinBuilder(joinTypeInner, tableName, "", leftCond, rightCond, fn)
 }
 
 // LeftJoin creates a table left join clause, like LEFT JOIN `table1` ON `table1.id` = `table2.table1_id`
 func (b *Build) LeftJoin(tableName, leftCond, rightCond string, fn WhereGroupFunc) Builder {
-	return b.getJoinBuilder(joinTypeLeft, tableName, leftCond, rightCond, fn)
+	return b.getJoinBuilder(joinTypeLeft, tableName, "", leftCond, rightCond, fn)
 }
 
 // RightJoin creates a table right join clause, like RIGHT JOIN `table1` ON `table1.id` = `table2.table1_id`
 func (b *Build) RightJoin(tableName, leftCond, rightCond string, fn WhereGroupFunc) Builder {
-	return b.getJoinBuilder(joinTypeRight, tableName, leftCond, rightCond, fn)
+	return b.getJoinBuilder(joinTypeRight, tableName, "", leftCond, rightCond, fn)
+}
+
+// FullJoin creates a table full join clause, like FULL JOIN `table1` ON `table1.id` = `table2.table1_id`
+func (b *Build) FullJoin(tableName, leftCond, rightCond string, fn WhereGroupFunc) Builder {
+	return b.getJoinBuilder(joinTypeFull, tableName, "", leftCond, rightCond, fn)
+}
+
+// CrossJoin creates a cross join clause without condition, like CROSS JOIN `table1`
+func (b *Build) CrossJoin(tableName string) Builder {
+	return b.getJoinBuilder(joinTypeCross, tableName, "", "", "", func(Where) {})
+}
+
+// NaturalJoin creates a natural join clause on the equally named columns, like NATURAL JOIN `table1`
+func (b *Build) NaturalJoin(tableName string) Builder {
+	return b.getJoinBuilder(joinTypeNatural, tableName, "", "", "", func(Where) {})
+}
+
+// LateralJoin creates a join on a subquery referencing the preceding tables, like CROSS JOIN LATERAL (SELECT ...) AS `alias`,
+// rendered as CROSS APPLY when the SQL flavour has no LATERAL
+func (b *Build) LateralJoin(sub Builder, alias string) Builder {
+	return b.appendJoin(&Join{
+		joinType: joinTypeLateral,
+		sub:      sub,
+		alias:    alias,
+	}, func(Where) {})
+}
+
+// JoinAs creates a table join clause with table alias, like JOIN `table1` AS `t1` ON `t1`.`id` = `table2`.`table1_id`
+func (b *Build) JoinAs(tableName, alias, leftCond, rightCond string, fn WhereGroupFunc) Builder {
+	return b.getJoinBuilder(joinTypeInner, tableName, alias, leftCond, rightCond, fn)
+}
+
+// LeftJoinAs creates a table left join clause with table alias, like LEFT JOIN `table1` AS `t1` ON `t1`.`id` = `table2`.`table1_id`
+func (b *Build) LeftJoinAs(tableName, alias, leftCond, rightCond string, fn WhereGroupFunc) Builder {
+	return b.getJoinBuilder(joinTypeLeft, tableName, alias, leftCond, rightCond, fn)
+}
+
+// RightJoinAs creates a table right join clause with table alias, like RIGHT JOIN `table1` AS `t1` ON `t1`.`id` = `table2`.`table1_id`
+func (b *Build) RightJoinAs(tableName, alias, leftCond, rightCond string, fn WhereGroupFunc) Builder {
+	return b.getJoinBuilder(joinTypeRight, tableName, alias, leftCond, rightCond, fn)
+}
+
+// JoinOn creates a table join clause with the ON condition built in the closure, like
+// JOIN `table1` ON `table1`.`id`=`table2`.`table1_id` OR `table1`.`code`=?
+func (b *Build) JoinOn(tableName string, fn OnFunc) Builder {
+	return b.getJoinBuilder(joinTypeInner, tableName, "", "", "", WhereGroupFunc(fn))
+}
+
+// LeftJoinOn creates a table left join clause with the ON condition built in the closure
+func (b *Build) LeftJoinOn(tableName string, fn OnFunc) Builder {
+	return b.getJoinBuilder(joinTypeLeft, tableName, "", "", "", WhereGroupFunc(fn))
+}
+
+// RightJoinOn creates a table right join clause with the ON condition built in the closure
+func (b *Build) RightJoinOn(tableName string, fn OnFunc) Builder {
+	return b.getJoinBuilder(joinTypeRight, tableName, "", "", "", WhereGroupFunc(fn))
+}
+
+// JoinUsing creates a table join clause on the equally named columns, like JOIN `table1` USING (`id`,`shop_id`).
+// Without USING support it renders the equality of the columns of the joined table and the FROM table
+func (b *Build) JoinUsing(tableName string, columns ...string) Builder {
+	return b.appendJoin(&Join{
+		joinType:  joinTypeInner,
+		tableName: tableName,
+		using:     columns,
+	}, func(Where) {})
+}
+
+// JoinSub creates a join clause on a subquery, like JOIN (SELECT ...) AS `alias` ON `alias`.`id` = `table2`.`table1_id`
+func (b *Build) JoinSub(sub Builder, alias, leftCond, rightCond string, fn WhereGroupFunc) Builder {
+	return b.appendJoin(&Join{
+		joinType:  joinTypeInner,
+		sub:       sub,
+		alias:     alias,
+		leftCond:  leftCond,
+		rightCond: rightCond,
+	}, fn)
 }
 
-func (b *Build) getJoinBuilder(joinType string, tableName, leftCond, rightCond string, fn WhereGroupFunc) Builder {
-	where := NewBlankWhere()
-	join := &Join{
+func (b *Build) getJoinBuilder(joinType string, tableName, alias, leftCond, rightCond string, fn WhereGroupFunc) Builder {
+	return b.appendJoin(&Join{
 		joinType:  joinType,
 		tableName: tableName,
+		alias:     alias,
 		leftCond:  leftCond,
 		rightCond: rightCond,
-		where:     where,
-	}
-	fn(where)
+	}, fn)
+}
+
+func (b *Build) appendJoin(join *Join, fn WhereGroupFunc) Builder {
+	join.where = b.newWhere()
+	fn(join.where)
 	b.joins = append(b.joins, join)
 	return b
 }
@@ -55,16 +151,88 @@ func (b *Build) generateJoin(j *Join) string {
 	builder := &strings.Builder{}
 	builderConcat(
 		builder,
-		" ", j.joinType, " ",
-		b.fieldQuote, j.tableName, b.fieldQuote,
-		" ", tokenOn, " ",
-		b.fieldQuote, j.leftCond, b.fieldQuote,
+		" ", b.getJoinKeyword(j.joinType), " ",
+		b.getSourceReference(j.tableName, j.sub, j.alias),
+	)
+	switch j.joinType {
+	case joinTypeCross, joinTypeNatural, joinTypeLateral:
+		return builder.String()
+	}
+
+	if len(j.using) > 0 {
+		builder.WriteString(b.generateUsing(j))
+		return builder.String()
+	}
+
+	builderConcat(builder, " ", tokenOn, " ")
+	if j.leftCond == "" && j.rightCond == "" {
+		onSQL := b.generateWhere(j.where)
+		if onSQL == "" {
+			b.setError(fmt.Errorf("%w: %s", ErrEmptyJoinCondition, j.tableName))
+		}
+		builder.WriteString(onSQL)
+		return builder.String()
+	}
+
+	builderConcat(
+		builder,
+		b.quote(j.leftCond),
 		"=",
-		b.fieldQuote, j.rightCond, b.fieldQuote,
-		" ",
+		b.quote(j.rightCond),
 	)
-	if j.where != nil {
-		builderConcat(builder, " AND ", b.generateWhere(j.where))
+	if whereSQL := b.generateWhere(j.where); whereSQL != "" {
+		builderConcat(builder, " AND ", whereSQL)
+	}
+
+	return builder.String()
+}
+
+// getJoinKeyword returns the keyword of the join type, recording ErrNotSupported when the SQL flavour lacks it
+func (b *Build) getJoinKeyword(joinType string) string {
+	feature := 0
+	switch joinType {
+	case joinTypeRight:
+		feature = FeatureRightJoin
+	case joinTypeFull:
+		feature = FeatureFullJoin
+	case joinTypeNatural:
+		feature = FeatureNaturalJoin
+	case joinTypeLateral:
+		if !b.dialect.Supports(FeatureLateral) && b.dialect.Supports(FeatureCrossApply) {
+			return joinTypeApply
+		}
+		feature = FeatureLateral
+	}
+
+	if feature != 0 && !b.dialect.Supports(feature) {
+		b.setError(fmt.Errorf("%w: %s", ErrNotSupported, joinType))
+	}
+
+	return joinType
+}
+
+func (b *Build) generateUsing(j *Join) string {
+	if b.dialect.Supports(FeatureJoinUsing) {
+		return " USING (" + b.getFieldList(j.using) + ")"
+	}
+
+	left := b.tableAlias
+	if left == "" {
+		left = b.tableName
+	}
+
+	right := j.tableName
+	if j.alias != "" {
+		right = j.alias
+	}
+
+	builder := &strings.Builder{}
+	builderConcat(builder, " ", tokenOn, " ")
+	for i, column := range j.using {
+		if i > 0 {
+			builder.WriteString(" AND ")
+		}
+		builderConcat(builder, b.quote(right+"."+column), "=", b.quote(left+"."+column))
 	}
 
 	return builder.String()
@@ -0,0 +1,97 @@
+package builder
+
+// Op is a relational operator of a WHERE condition, like = or LIKE. Dialects render it through Dialect.Operator
+type Op string
+
+// Operators known by the built-in dialects. The string relations of Where, like "=" or "!=", are the same values
+const (
+	Eq                Op = "="
+	Ne                Op = "<>"
+	Gt                Op = ">"
+	Gte               Op = ">="
+	Lt                Op = "<"
+	Lte               Op = "<="
+	Like              Op = "LIKE"
+	NotLike           Op = "NOT LIKE"
+	ILike             Op = "ILIKE"
+	Regexp            Op = "REGEXP"
+	IsDistinctFrom    Op = "IS DISTINCT FROM"
+	IsNotDistinctFrom Op = "IS NOT DISTINCT FROM"
+	ArrayContains     Op = "@>"
+	ArrayContainedBy  Op = "<@"
+	Overlaps          Op = "&&"
+)
+
+// ansiOperators are the formats of BaseDialect.Operator, the first %s is the field, the second is the value
+var ansiOperators = map[Op]string{
+	Eq:                "%s=%s",
+	Ne:                "%s<>%s",
+	"!=":              "%s!=%s",
+	Gt:                "%s>%s",
+	Gte:               "%s>=%s",
+	Lt:                "%s<%s",
+	Lte:               "%s<=%s",
+	Like:              "%s LIKE %s",
+	NotLike:           "%s NOT LIKE %s",
+	ILike:             "LOWER(%s) LIKE LOWER(%s)",
+	IsDistinctFrom:    "%s IS DISTINCT FROM %s",
+	IsNotDistinctFrom: "%s IS NOT DISTINCT FROM %s",
+}
+
+var sqLiteOperators = map[Op]string{
+	Regexp:            "%s REGEXP %s",
+	IsDistinctFrom:    "%s IS NOT %s",
+	IsNotDistinctFrom: "%s IS %s",
+}
+
+var mySQLOperators = map[Op]string{
+	Regexp:            "%s REGEXP %s",
+	IsDistinctFrom:    "NOT (%s <=> %s)",
+	IsNotDistinctFrom: "%s <=> %s",
+}
+
+var pgSQLOperators = map[Op]string{
+	ILike:            "%s ILIKE %s",
+	Regexp:           "%s ~ %s",
+	ArrayContains:    "%s @> %s",
+	ArrayContainedBy: "%s <@ %s",
+	Overlaps:         "%s && %s",
+}
+
+var mSSQLOperators = map[Op]string{
+	IsDistinctFrom:    "NOT EXISTS (SELECT %s INTERSECT SELECT %s)",
+	IsNotDistinctFrom: "EXISTS (SELECT %s INTERSECT SELECT %s)",
+}
+
+var oracleOperators = map[Op]string{
+	Regexp:            "REGEXP_LIKE(%s, %s)",
+	IsDistinctFrom:    "DECODE(%s, %s, 0, 1)=1",
+	IsNotDistinctFrom: "DECODE(%s, %s, 0, 1)=0",
+}
+
+// registeredOperators are the operators accepted when a condition is created, the dialect decides at AsSQL if it can render them
+var registeredOperators = map[Op]bool{}
+
+func init() {
+	for _, operators := range []map[Op]string{ansiOperators, sqLiteOperators, mySQLOperators, pgSQLOperators, mSSQLOperators, oracleOperators} {
+		for op := range operators {
+			RegisterOperator(op)
+		}
+	}
+}
+
+// RegisterOperator accepts an additional operator in WHERE conditions, rendered by the Operator method of a custom dialect.
+// It is not safe for concurrent use, call it from an init function
+func RegisterOperator(op Op) {
+	registeredOperators[op] = true
+}
+
+// lookupOperator returns the format of the operator from the dialect specific table, falling back to the ANSI one
+func lookupOperator(operators map[Op]string, op Op) (string, bool) {
+	if format, ok := operators[op]; ok {
+		return format, true
+	}
+
+	format, ok := ansiOperators[op]
+	return format, ok
+}
@@ -0,0 +1,104 @@
+package builder
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	// OrderAsc sorts in ascending order
+	OrderAsc = "ASC"
+	// OrderDesc sorts in descending order
+	OrderDesc = "DESC"
+
+	// NullsDefault leaves the position of NULL values to the SQL engine
+	NullsDefault = 0
+	// NullsFirst sorts NULL values before the others
+	NullsFirst = 1
+	// NullsLast sorts NULL values after the others
+	NullsLast = 2
+)
+
+var (
+	ErrInvalidOrder = errors.New("invalid ORDER BY direction")
+)
+
+// orderItem is an entry of the ORDER BY clause
+type orderItem struct {
+	field     string
+	direction string
+	nulls     int
+}
+
+// OrderBy adds fields to the SQL ORDER BY clause, multiple calls are accumulated
+func (b *Build) OrderBy(fields ...string) Builder {
+	return b.appendOrderBy("", fields)
+}
+
+// OrderByAsc adds fields to the SQL ORDER BY clause in ascending order
+func (b *Build) OrderByAsc(fields ...string) Builder {
+	return b.appendOrderBy(OrderAsc, fields)
+}
+
+// OrderByDesc adds fields to the SQL ORDER BY clause in descending order
+func (b *Build) OrderByDesc(fields ...string) Builder {
+	return b.appendOrderBy(OrderDesc, fields)
+}
+
+// OrderByExpr adds a field to the SQL ORDER BY clause with direction (OrderAsc, OrderDesc or empty) and NULL ordering,
+// like `field` DESC NULLS LAST. NULLS FIRST/LAST is emulated on SQL engines not supporting it
+func (b *Build) OrderByExpr(field, direction string, nulls int) Builder {
+	b.orderBy = append(b.orderBy, &orderItem{field: field, direction: direction, nulls: nulls})
+	return b
+}
+
+func (b *Build) appendOrderBy(direction string, fields []string) Builder {
+	for _, field := range fields {
+		b.orderBy = append(b.orderBy, &orderItem{field: field, direction: direction})
+	}
+
+	return b
+}
+
+func (b *Build) getOrderBy() string {
+	strBuilder := &strings.Builder{}
+	for i, item := range b.orderBy {
+		if i > 0 {
+			strBuilder.WriteString(",")
+		}
+		strBuilder.WriteString(b.getOrderItem(item))
+	}
+
+	return strBuilder.String()
+}
+
+func (b *Build) getOrderItem(item *orderItem) string {
+	field := b.quote(item.field)
+	direction := strings.ToUpper(item.direction)
+	if direction != "" && direction != OrderAsc && direction != OrderDesc {
+		b.setError(fmt.Errorf("%w: %s", ErrInvalidOrder, item.direction))
+	}
+
+	if direction != "" {
+		field += " " + direction
+	}
+
+	switch item.nulls {
+	case NullsDefault:
+		return field
+	case NullsFirst:
+		if b.dialect.Supports(FeatureNullsOrdering) {
+			return field + " NULLS FIRST"
+		}
+		return "CASE WHEN " + b.quote(item.field) + " IS NULL THEN 0 ELSE 1 END," + field
+	case NullsLast:
+		if b.dialect.Supports(FeatureNullsOrdering) {
+			return field + " NULLS LAST"
+		}
+		return "CASE WHEN " + b.quote(item.field) + " IS NULL THEN 1 ELSE 0 END," + field
+	default:
+		b.setError(fmt.Errorf("%w: invalid NULLS ordering %d", ErrInvalidOrder, item.nulls))
+		return field
+	}
+}
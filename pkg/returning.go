@@ -0,0 +1,59 @@
+package builder
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	outputInserted = "INSERTED"
+	outputDeleted  = "DELETED"
+)
+
+// Returning sets the columns of the inserted, updated or deleted rows returned by the statement,
+// rendered as RETURNING or as OUTPUT INSERTED.x / DELETED.x on MSSQL
+func (b *Build) Returning(columns ...string) Builder {
+	b.returning = columns
+	return b
+}
+
+// generateOutput renders the OUTPUT clause of dialects with ReturningOutput, taking the columns of the given pseudo table
+func (b *Build) generateOutput(table string) string {
+	if len(b.returning) == 0 || b.dialect.ReturningStyle() != ReturningOutput {
+		return ""
+	}
+
+	builder := &strings.Builder{}
+	builder.WriteString(" OUTPUT ")
+	for i, column := range b.returning {
+		if i > 0 {
+			builder.WriteString(",")
+		}
+		builderConcat(builder, table, ".", b.quote(column))
+	}
+
+	return builder.String()
+}
+
+// generateReturning renders the trailing RETURNING clause of dialects with ReturningClause,
+// recording ErrNotSupported when the dialect cannot return the rows of the statement
+func (b *Build) generateReturning() string {
+	if len(b.returning) == 0 {
+		return ""
+	}
+
+	switch b.dialect.ReturningStyle() {
+	case ReturningClause:
+		if b.sQLType == typeUpdate && !b.dialect.Supports(FeatureUpdateReturning) {
+			b.setError(fmt.Errorf("%w: RETURNING in UPDATE", ErrNotSupported))
+			return ""
+		}
+
+		return " RETURNING " + b.getFieldList(b.returning)
+	case ReturningOutput:
+		return ""
+	default:
+		b.setError(fmt.Errorf("%w: RETURNING", ErrNotSupported))
+		return ""
+	}
+}
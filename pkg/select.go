@@ -1,7 +1,6 @@
 package builder
 
 import (
-	"strconv"
 	"strings"
 )
 
@@ -13,15 +12,24 @@ func (b *Build) Select(tableName string) Builder {
 	return b
 }
 
-// GroupBy adds a SQL GROUP BY clause
-func (b *Build) GroupBy(fields ...string) Builder {
-	b.groupBy = fields
+// SelectAs initiates a Select SQL statement with table alias like 'SELECT <fieldlist> FROM `table` AS `alias`'
+func (b *Build) SelectAs(tableName, alias string) Builder {
+	b.Select(tableName)
+	b.tableAlias = alias
 	return b
 }
 
-// OrderBy adds a SQL ORDER BY clause
-func (b *Build) OrderBy(fields ...string) Builder {
-	b.orderBy = fields
+// SelectFrom initiates a Select SQL statement from a derived table like 'SELECT <fieldlist> FROM (SELECT ...) AS `alias`'
+func (b *Build) SelectFrom(sub Builder, alias string) Builder {
+	b.Select("")
+	b.fromSub = sub
+	b.tableAlias = alias
+	return b
+}
+
+// GroupBy adds a SQL GROUP BY clause
+func (b *Build) GroupBy(fields ...string) Builder {
+	b.groupBy = fields
 	return b
 }
 
@@ -38,13 +46,27 @@ func (b *Build) Offset(o int) Builder {
 }
 
 func (b *Build) generateSelectSQL() (string, error) {
+	paginationPrefix, paginationSuffix := b.dialect.Pagination(b.limit, b.offset)
+	if len(b.compounds) > 0 {
+		return b.generateCompoundSQL(paginationPrefix, paginationSuffix), nil
+	}
+
+	return b.generateSelectCore(paginationPrefix) + b.generateSelectTail(paginationSuffix), nil
+}
+
+// generateSelectCore renders the SELECT statement up to and including the HAVING clause
+func (b *Build) generateSelectCore(paginationPrefix string) string {
 	builder := &strings.Builder{}
+	builder.WriteString("SELECT ")
+	if paginationPrefix != "" {
+		builderConcat(builder, paginationPrefix, " ")
+	}
+
 	builderConcat(
 		builder,
-		"SELECT ",
 		b.getSelectFields(),
 		" FROM ",
-		b.fieldQuote, b.tableName, b.fieldQuote,
+		b.getSourceReference(b.tableName, b.fromSub, b.tableAlias),
 	)
 
 	builderConcat(
@@ -67,29 +89,40 @@ func (b *Build) generateSelectSQL() (string, error) {
 		)
 	}
 
-	orderBySQL := b.getOrderBy()
-	if orderBySQL != "" {
+	havingSQL := b.generateWhere(b.having)
+	if havingSQL != "" {
 		builderConcat(
 			builder,
-			" ORDER BY ", orderBySQL,
+			" ", tokenHaving, " ", havingSQL,
 		)
 	}
 
-	if b.limit > 0 {
+	return builder.String()
+}
+
+// generateSelectTail renders the ORDER BY clause and the pagination suffix
+func (b *Build) generateSelectTail(paginationSuffix string) string {
+	builder := &strings.Builder{}
+	orderBySQL := b.getOrderBy()
+	if orderBySQL == "" && paginationSuffix != "" {
+		orderBySQL = b.dialect.PaginationOrderBy()
+	}
+
+	if orderBySQL != "" {
 		builderConcat(
 			builder,
-			" LIMIT ", strconv.Itoa(b.limit),
+			" ORDER BY ", orderBySQL,
 		)
 	}
 
-	if b.offset > 0 {
+	if paginationSuffix != "" {
 		builderConcat(
 			builder,
-			" OFFSET ", strconv.Itoa(b.offset),
+			" ", paginationSuffix,
 		)
 	}
 
-	return builder.String(), nil
+	return builder.String()
 }
 
 func (b *Build) getSelectFields() string {
@@ -97,7 +130,7 @@ func (b *Build) getSelectFields() string {
 		return "*"
 	}
 
-	return b.getFieldList(b.fields)
+	return b.getColumnList(b.fields)
 }
 
 func (b *Build) getGroupBy() string {
@@ -108,23 +141,26 @@ func (b *Build) getGroupBy() string {
 	return b.getFieldList(b.groupBy)
 }
 
-func (b *Build) getOrderBy() string {
-	if len(b.orderBy) == 0 {
-		return ""
+func (b *Build) getSelectParams() []interface{} {
+	pars := b.getColumnParams()
+	if b.fromSub != nil {
+		pars = append(pars, b.getSubParams(b.fromSub)...)
 	}
 
-	return b.getFieldList(b.orderBy)
-}
-
-func (b *Build) getSelectParams() []interface{} {
-	var pars []interface{}
 	for _, join := range b.joins {
+		if join.sub != nil {
+			pars = append(pars, b.getSubParams(join.sub)...)
+		}
+
 		if join.where != nil {
 			pars = append(pars, b.getWhereParams(join.where)...)
 		}
 	}
 
-	return append(pars, b.getWhereParams(b.where)...)
+	pars = append(pars, b.getWhereParams(b.where)...)
+	pars = append(pars, b.getWhereParams(b.having)...)
+
+	return append(pars, b.getCompoundParams()...)
 }
 
 func (b *Build) getFieldList(fl []string) string {
@@ -133,15 +169,7 @@ func (b *Build) getFieldList(fl []string) string {
 		if i > 0 {
 			strBuilder.WriteString(",")
 		}
-		if b.fieldsAreRaw {
-			builderConcat(strBuilder, fn)
-		} else {
-			builderConcat(
-				strBuilder,
-				b.fieldQuote, fn, b.fieldQuote,
-			)
-		}
-
+		strBuilder.WriteString(b.quote(fn))
 	}
 
 	return strBuilder.String()
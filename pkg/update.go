@@ -14,6 +14,10 @@ func (b *Build) Update(tableName string) Builder {
 }
 
 func (b *Build) generateUpdateSQL() (string, error) {
+	if err := b.checkColumnParams(); err != nil {
+		return "", err
+	}
+
 	valueCount := len(b.values)
 	if len(b.fields) != valueCount {
 		return "", errFieldCountMismatch
@@ -26,19 +30,20 @@ func (b *Build) generateUpdateSQL() (string, error) {
 	builder := &strings.Builder{}
 	builderConcat(
 		builder,
-		"UPDATE ", b.fieldQuote, b.tableName, b.fieldQuote,
+		"UPDATE ", b.quote(b.tableName),
 		" SET ",
 	)
 
-	for i, fn := range b.fields {
+	for i, c := range b.fields {
 		if i > 0 {
 			builder.WriteString(",")
 		}
 		builderConcat(
 			builder,
-			b.fieldQuote, fn, b.fieldQuote, "=", b.getBindingParameter(),
+			b.getColumnName(c), "=", b.getBindingParameter(),
 		)
 	}
+	builder.WriteString(b.generateOutput(outputInserted))
 
 	whereSQL := b.generateWhere(b.where)
 	if whereSQL != "" {
@@ -47,6 +52,7 @@ func (b *Build) generateUpdateSQL() (string, error) {
 			" ", tokenWhere, " ", whereSQL,
 		)
 	}
+	builder.WriteString(b.generateReturning())
 
 	return builder.String(), nil
 }
@@ -0,0 +1,306 @@
+package builder
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// upsertSource is the alias of the inserted rows in MERGE, named like the EXCLUDED pseudo table of ON CONFLICT
+	upsertSource = "excluded"
+	// mySQLRowAlias is the alias of the inserted row in ON DUPLICATE KEY UPDATE
+	mySQLRowAlias = "new"
+)
+
+// upsert is the conflict handling of an INSERT, set by OnConflict, DoUpdate and DoNothing
+type upsert struct {
+	columns []string
+	update  []string
+	nothing bool
+}
+
+// OnConflict sets the columns of the unique key the INSERT may conflict on, followed by DoUpdate or DoNothing.
+// MySQL ignores the columns, it handles the conflict of any unique key
+func (b *Build) OnConflict(columns ...string) Builder {
+	b.getUpsert().columns = columns
+	return b
+}
+
+// DoUpdate updates the given fields of the conflicting row with the inserted values, or all the inserted fields
+// except the conflict columns when no field is given. The Where conditions of the INSERT restrict this update, in a MERGE
+// their fields without table name refer to the table
+func (b *Build) DoUpdate(fields ...string) Builder {
+	u := b.getUpsert()
+	u.update = fields
+	u.nothing = false
+	return b
+}
+
+// DoNothing skips the inserted rows which conflict with an existing one
+func (b *Build) DoNothing() Builder {
+	u := b.getUpsert()
+	u.update = nil
+	u.nothing = true
+	return b
+}
+
+func (b *Build) getUpsert() *upsert {
+	if b.upsert == nil {
+		b.upsert = &upsert{}
+	}
+
+	return b.upsert
+}
+
+// getUpsertFields returns the fields updated on conflict
+func (b *Build) getUpsertFields() []string {
+	if len(b.upsert.update) > 0 {
+		return b.upsert.update
+	}
+
+	var fields []string
+	for _, c := range b.fields {
+		if !containsString(b.upsert.columns, c.name) {
+			fields = append(fields, c.name)
+		}
+	}
+
+	return fields
+}
+
+func (b *Build) isUpsertNothing() bool {
+	return b.upsert.nothing || len(b.getUpsertFields()) == 0
+}
+
+// generateUpsertWhere renders the conditions of the update on conflict, which are not valid without update
+func (b *Build) generateUpsertWhere() string {
+	if len(b.where.GetItems()) == 0 {
+		return ""
+	}
+
+	if b.isUpsertNothing() {
+		b.setError(fmt.Errorf("%w: WHERE without DoUpdate", ErrNotSupported))
+		return ""
+	}
+
+	return b.generateWhere(b.where)
+}
+
+// generateAssignments renders field=value for each field, where value returns the new value of the field
+func (b *Build) generateAssignments(fields []string, value func(field string) string) string {
+	builder := &strings.Builder{}
+	for i, field := range fields {
+		if i > 0 {
+			builder.WriteString(",")
+		}
+		builderConcat(builder, b.quote(field), "=", value(field))
+	}
+
+	return builder.String()
+}
+
+// generateUpsertClause renders the ON CONFLICT or ON DUPLICATE KEY UPDATE clause following the inserted rows
+func (b *Build) generateUpsertClause() string {
+	if b.upsert == nil {
+		return ""
+	}
+
+	builder := &strings.Builder{}
+	switch b.dialect.UpsertStyle() {
+	case UpsertOnConflict:
+		builder.WriteString(" ON CONFLICT")
+		if len(b.upsert.columns) > 0 {
+			builderConcat(builder, " (", b.getFieldList(b.upsert.columns), ")")
+		}
+
+		whereSQL := b.generateUpsertWhere()
+		if b.isUpsertNothing() {
+			builder.WriteString(" DO NOTHING")
+			break
+		}
+
+		if len(b.upsert.columns) == 0 && !b.dialect.Supports(FeatureUpsertWithoutTarget) {
+			b.setError(fmt.Errorf("%w: DO UPDATE without OnConflict columns", ErrNotSupported))
+		}
+
+		builderConcat(builder, " DO UPDATE SET ", b.generateAssignments(b.getUpsertFields(), func(field string) string {
+			return "EXCLUDED." + b.quote(field)
+		}))
+		if whereSQL != "" {
+			builderConcat(builder, " ", tokenWhere, " ", whereSQL)
+		}
+	case UpsertOnDuplicateKey:
+		if len(b.where.GetItems()) > 0 {
+			b.setError(fmt.Errorf("%w: WHERE in ON DUPLICATE KEY UPDATE", ErrNotSupported))
+		}
+
+		if b.isUpsertNothing() {
+			first := b.quote(b.fields[0].name)
+			builderConcat(builder, " ON DUPLICATE KEY UPDATE ", first, "=", first)
+			break
+		}
+
+		value := func(field string) string {
+			return "VALUES(" + b.quote(field) + ")"
+		}
+		if b.insertFrom == nil && b.dialect.Supports(FeatureInsertRowAlias) {
+			builderConcat(builder, " AS ", b.quoteAlias(mySQLRowAlias))
+			value = func(field string) string {
+				return b.quoteAlias(mySQLRowAlias) + "." + b.quote(field)
+			}
+		}
+		builderConcat(builder, " ON DUPLICATE KEY UPDATE ", b.generateAssignments(b.getUpsertFields(), value))
+	}
+
+	return builder.String()
+}
+
+// generateUpdateOrInsertSQL renders UPDATE OR INSERT, which updates every inserted field of a single row
+func (b *Build) generateUpdateOrInsertSQL() (string, error) {
+	if err := b.validateInsertRows(); err != nil {
+		return "", err
+	}
+
+	switch {
+	case b.insertFrom != nil || len(b.getInsertRows()) > 1:
+		return "", fmt.Errorf("%w: UPDATE OR INSERT of multiple rows", ErrNotSupported)
+	case b.upsert.nothing:
+		return "", fmt.Errorf("%w: UPDATE OR INSERT without update", ErrNotSupported)
+	case len(b.where.GetItems()) > 0:
+		return "", fmt.Errorf("%w: WHERE in UPDATE OR INSERT", ErrNotSupported)
+	}
+
+	updated := b.getUpsertFields()
+	for _, c := range b.fields {
+		if !containsString(updated, c.name) && !containsString(b.upsert.columns, c.name) {
+			return "", fmt.Errorf("%w: UPDATE OR INSERT updates every inserted field, %s is not updated", ErrNotSupported, c.name)
+		}
+	}
+
+	builder := &strings.Builder{}
+	builderConcat(
+		builder,
+		"UPDATE OR INSERT INTO ", b.quote(b.tableName),
+		" (", b.getColumnNames(), ")",
+		" VALUES (", b.getValuePlaceholders(len(b.fields)), ")",
+	)
+	if len(b.upsert.columns) > 0 {
+		builderConcat(builder, " MATCHING (", b.getFieldList(b.upsert.columns), ")")
+	}
+	builder.WriteString(b.generateReturning())
+
+	return builder.String(), nil
+}
+
+// generateMergeSQL renders a MERGE statement joining the inserted rows, aliased as excluded, to the table on the conflict columns
+func (b *Build) generateMergeSQL() (string, error) {
+	if b.insertFrom == nil {
+		if err := b.validateInsertRows(); err != nil {
+			return "", err
+		}
+	}
+
+	if len(b.upsert.columns) == 0 {
+		return "", fmt.Errorf("%w: MERGE without OnConflict columns", ErrNotSupported)
+	}
+
+	table := b.quote(b.tableName)
+	source := b.quoteAlias(upsertSource)
+	builder := &strings.Builder{}
+	builderConcat(builder, "MERGE INTO ", table, " USING ", b.generateMergeSource(source), " ON (")
+	for i, column := range b.upsert.columns {
+		if i > 0 {
+			builder.WriteString(" AND ")
+		}
+		builderConcat(builder, table, ".", b.quote(column), "=", source, ".", b.quote(column))
+	}
+	builder.WriteString(")")
+
+	// both the table and the source have the fields, the conditions refer to the table unless they name one
+	b.fieldTable = b.tableName
+	whereSQL := b.generateUpsertWhere()
+	b.fieldTable = ""
+	if !b.isUpsertNothing() {
+		builder.WriteString(" WHEN MATCHED")
+		if whereSQL != "" && !b.dialect.Supports(FeatureMergeUpdateWhere) {
+			builderConcat(builder, " AND (", whereSQL, ")")
+		}
+		builderConcat(builder, " THEN UPDATE SET ", b.generateAssignments(b.getUpsertFields(), func(field string) string {
+			return source + "." + b.quote(field)
+		}))
+		if whereSQL != "" && b.dialect.Supports(FeatureMergeUpdateWhere) {
+			builderConcat(builder, " ", tokenWhere, " ", whereSQL)
+		}
+	}
+
+	builderConcat(builder, " WHEN NOT MATCHED THEN INSERT (", b.getColumnNames(), ") VALUES (")
+	for i, c := range b.fields {
+		if i > 0 {
+			builder.WriteString(",")
+		}
+		builderConcat(builder, source, ".", b.quote(c.name))
+	}
+	builderConcat(builder, ")", b.generateOutput(outputInserted), b.generateReturning())
+
+	if b.dialect.Supports(FeatureMergeTerminator) {
+		builder.WriteString(";")
+	}
+
+	return builder.String(), nil
+}
+
+// generateMergeSource renders the inserted rows as a derived table. Dialects with multi-row VALUES get VALUES and a
+// column list, the others a UNION ALL of SELECTs naming the columns, and a select of FromSelect must name its columns as the fields
+func (b *Build) generateMergeSource(source string) string {
+	columnList := " (" + b.getColumnNames() + ")"
+	if b.insertFrom != nil {
+		sql := b.dialect.TableAlias("("+b.generateSubSQL(b.insertFrom)+")", source)
+		if b.dialect.MultiRowStyle() == MultiRowValues {
+			sql += columnList
+		}
+
+		return sql
+	}
+
+	builder := &strings.Builder{}
+	if b.dialect.MultiRowStyle() == MultiRowValues {
+		builder.WriteString("(VALUES ")
+		for i, row := range b.getInsertRows() {
+			if i > 0 {
+				builder.WriteString(",")
+			}
+			builderConcat(builder, "(", b.getValuePlaceholders(len(row)), ")")
+		}
+		builder.WriteString(")")
+
+		return b.dialect.TableAlias(builder.String(), source) + columnList
+	}
+
+	builder.WriteString("(")
+	for i := range b.getInsertRows() {
+		if i > 0 {
+			builder.WriteString(" UNION ALL ")
+		}
+		builder.WriteString("SELECT ")
+		for j, c := range b.fields {
+			if j > 0 {
+				builder.WriteString(",")
+			}
+			builderConcat(builder, b.getBindingParameter(), " AS ", b.quote(c.name))
+		}
+		builder.WriteString(b.getDummyFrom())
+	}
+	builder.WriteString(")")
+
+	return b.dialect.TableAlias(builder.String(), source)
+}
+
+func containsString(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -15,17 +15,47 @@ const (
 	typeNotIn                         = 9
 	typeOrIn                          = 10
 	typeOrNotIn                       = 11
+	typeInSub                         = 12
+	typeNotInSub                      = 13
+	typeExists                        = 14
+	typeNotExists                     = 15
+	typeWhereSub                      = 16
+	typeLike                          = 17
+	typeOrLike                        = 18
+	typeNotLike                       = 19
+	typeILike                         = 20
+	typeColumn                        = 21
+	typeOrColumn                      = 22
 	tokenWhere                        = "WHERE"
 	tokenOn                           = "ON"
 	incorrectRelationshipPanicMessage = "provided relation %s is not valid"
 )
 
+// InvalidRelationError is returned by AsSQL when a WHERE condition was created with an unknown relation
+type InvalidRelationError struct {
+	Field    string
+	Relation string
+}
+
+func (e *InvalidRelationError) Error() string {
+	return fmt.Sprintf(incorrectRelationshipPanicMessage, e.Relation) + " for field " + e.Field
+}
+
+func checkRelation(field, relation string) error {
+	if !validateRelation(relation) {
+		return &InvalidRelationError{Field: field, Relation: relation}
+	}
+
+	return nil
+}
+
 // NewBlankWhere initiates a Where interface object with default values
 func NewBlankWhere() Where {
 	return &Wh{}
 }
 
-// NewWhere initiates a Where interface object propagating values for SQL WHERE statement
+// NewWhere initiates a Where interface object propagating values for SQL WHERE statement.
+// An invalid relation is recorded as *InvalidRelationError, returned by GetError and by AsSQL of the builder
 func NewWhere(
 	raw bool,
 	operator int,
@@ -33,15 +63,12 @@ func NewWhere(
 	relation string,
 	value interface{},
 ) Where {
-	if !validateRelation(relation) {
-		panic(fmt.Sprintf(incorrectRelationshipPanicMessage, relation))
-	}
-
 	return &Wh{
+		err:      checkRelation(field, relation),
 		raw:      raw,
 		operator: operator,
 		field:    field,
-		relation: relation,
+		relation: Op(relation),
 		value:    value,
 	}
 }
@@ -143,6 +170,97 @@ func NewOrNotIn(
 	}
 }
 
+// NewInSub is to generate field_name IN (SELECT ...)
+func NewInSub(
+	field string,
+	sub Builder,
+) Where {
+	return &Wh{
+		field:    field,
+		operator: typeInSub,
+		sub:      sub,
+	}
+}
+
+// NewNotInSub is to generate field_name NOT IN (SELECT ...)
+func NewNotInSub(
+	field string,
+	sub Builder,
+) Where {
+	return &Wh{
+		field:    field,
+		operator: typeNotInSub,
+		sub:      sub,
+	}
+}
+
+// NewExists is to generate EXISTS (SELECT ...)
+func NewExists(
+	sub Builder,
+) Where {
+	return &Wh{
+		operator: typeExists,
+		sub:      sub,
+	}
+}
+
+// NewNotExists is to generate NOT EXISTS (SELECT ...)
+func NewNotExists(
+	sub Builder,
+) Where {
+	return &Wh{
+		operator: typeNotExists,
+		sub:      sub,
+	}
+}
+
+// NewWhereSub is to generate field_name = (SELECT ...) with any valid relation
+func NewWhereSub(
+	field string,
+	relation string,
+	sub Builder,
+) Where {
+	return &Wh{
+		err:      checkRelation(field, relation),
+		field:    field,
+		relation: Op(relation),
+		operator: typeWhereSub,
+		sub:      sub,
+	}
+}
+
+// NewWhereColumn is to generate left_field = right_field, comparing two columns instead of binding a value
+func NewWhereColumn(
+	operator int,
+	left string,
+	relation string,
+	right string,
+) Where {
+	return &Wh{
+		err:      checkRelation(left, relation),
+		field:    left,
+		relation: Op(relation),
+		column:   right,
+		operator: operator,
+	}
+}
+
+// NewLike is to generate field_name LIKE ?, NOT LIKE or ILIKE depending on the operator.
+// Escaped patterns are rendered with an ESCAPE clause, see Dialect.EscapeLike
+func NewLike(
+	operator int,
+	field string,
+	pattern interface{},
+	escaped bool,
+) Where {
+	return &Wh{
+		operator: operator,
+		field:    field,
+		value:    pattern,
+		escaped:  escaped,
+	}
+}
+
 // NewBetween creates a new Between where object with parameter required for an SQL BETVEEN ? ands ? statement
 func NewBetween(
 	operator int,
@@ -165,6 +283,10 @@ type WhereGroupFunc func(Where)
 type Where interface {
 	Where(string, string, interface{}) Where
 	OrWhere(string, string, interface{}) Where
+	WhereOp(string, Op, interface{}) Where
+	OrWhereOp(string, Op, interface{}) Where
+	WhereColumn(string, string, string) Where
+	OrWhereColumn(string, string, string) Where
 	Between(string, interface{}, interface{}) Where
 	OrBetween(string, interface{}, interface{}) Where
 	WhereGroup(fn WhereGroupFunc) Where
@@ -177,6 +299,18 @@ type Where interface {
 	NotIn(string, ...interface{}) Where
 	OrIn(string, ...interface{}) Where
 	OrNotIn(string, ...interface{}) Where
+	InSub(string, Builder) Where
+	NotInSub(string, Builder) Where
+	Exists(Builder) Where
+	NotExists(Builder) Where
+	WhereSub(string, string, Builder) Where
+	Like(string, interface{}) Where
+	NotLike(string, interface{}) Where
+	OrLike(string, interface{}) Where
+	ILike(string, interface{}) Where
+	Contains(string, string) Where
+	StartsWith(string, string) Where
+	EndsWith(string, string) Where
 	GetItems() []Where
 	GetOperator() int
 	GetField() string
@@ -184,7 +318,11 @@ type Where interface {
 	GetValue() interface{}
 	GetValue2() interface{}
 	GetInValues() []interface{}
+	GetSub() Builder
 	GetIsRaw() bool
+	GetIsEscaped() bool
+	GetColumn() string
+	GetError() error
 	AppendItem(Where)
 }
 
@@ -193,57 +331,55 @@ type Wh struct {
 	raw      bool
 	operator int
 	field    string
-	relation string // TODO this could be a domain object verifying = <, > ...
+	relation Op
 	value    interface{}
 	value2   interface{}
 	inValues []interface{}
+	sub      Builder
+	column   string
+	escaped  bool
 	items    []Where
+	strict   bool
+	err      error
 }
 
 // Where creates SQL WHERE block
 func (w *Wh) Where(field, relation string, value interface{}) Where {
-	if !validateRelation(relation) {
-		panic(fmt.Sprintf(incorrectRelationshipPanicMessage, relation))
-	}
+	w.AppendItem(NewWhere(false, typeAnd, field, relation, value))
+	return w
+}
 
-	w.items = append(w.items, &Wh{
-		raw:      false,
-		field:    field,
-		relation: relation,
-		value:    value,
-		operator: typeAnd,
-	})
+// WhereOp creates SQL WHERE block with a typed operator, like WhereOp("name", Regexp, "^J")
+func (w *Wh) WhereOp(field string, op Op, value interface{}) Where {
+	return w.Where(field, string(op), value)
+}
+
+// OrWhereOp creates SQL OrWhere block with a typed operator
+func (w *Wh) OrWhereOp(field string, op Op, value interface{}) Where {
+	return w.OrWhere(field, string(op), value)
+}
+
+// WhereColumn creates SQL WHERE block comparing two columns, like `left` = `right`
+func (w *Wh) WhereColumn(left, relation, right string) Where {
+	w.AppendItem(NewWhereColumn(typeColumn, left, relation, right))
+	return w
+}
+
+// OrWhereColumn creates SQL OrWhere block comparing two columns
+func (w *Wh) OrWhereColumn(left, relation, right string) Where {
+	w.AppendItem(NewWhereColumn(typeOrColumn, left, relation, right))
 	return w
 }
 
 // RaWWhere creates SQL WHERE block
 func (w *Wh) RaWWhere(field, relation string, value interface{}) Where {
-	if !validateRelation(relation) {
-		panic(fmt.Sprintf(incorrectRelationshipPanicMessage, relation))
-	}
-
-	w.items = append(w.items, &Wh{
-		raw:      true,
-		field:    field,
-		relation: relation,
-		value:    value,
-		operator: typeAnd,
-	})
+	w.AppendItem(NewWhere(true, typeAnd, field, relation, value))
 	return w
 }
 
 // OrWhere creates SQL OrWhere block
 func (w *Wh) OrWhere(field, relation string, value interface{}) Where {
-	if !validateRelation(relation) {
-		panic(fmt.Sprintf(incorrectRelationshipPanicMessage, relation))
-	}
-
-	w.items = append(w.items, &Wh{
-		field:    field,
-		relation: relation,
-		value:    value,
-		operator: typeOr,
-	})
+	w.AppendItem(NewWhere(false, typeOr, field, relation, value))
 	return w
 }
 
@@ -273,7 +409,7 @@ func (w *Wh) OrBetween(field string, value1, value2 interface{}) Where {
 func (w *Wh) WhereGroup(fn WhereGroupFunc) Where {
 	where := &Wh{operator: typeAnd}
 
-	w.items = append(w.items, where)
+	w.AppendItem(where)
 	fn(where)
 
 	return w
@@ -283,7 +419,7 @@ func (w *Wh) WhereGroup(fn WhereGroupFunc) Where {
 func (w *Wh) OrWhereGroup(fn WhereGroupFunc) Where {
 	where := &Wh{operator: typeOr}
 
-	w.items = append(w.items, where)
+	w.AppendItem(where)
 	fn(where)
 
 	return w
@@ -353,6 +489,90 @@ func (w *Wh) OrNotIn(fileName string, pars ...interface{}) Where {
 	return w
 }
 
+// InSub generates where sql like AND `field` IN (SELECT ...)
+func (w *Wh) InSub(fileName string, sub Builder) Where {
+	w.items = append(w.items, NewInSub(fileName, sub))
+
+	return w
+}
+
+// NotInSub generates where sql like AND `field` NOT IN (SELECT ...)
+func (w *Wh) NotInSub(fileName string, sub Builder) Where {
+	w.items = append(w.items, NewNotInSub(fileName, sub))
+
+	return w
+}
+
+// Exists generates where sql like AND EXISTS (SELECT ...)
+func (w *Wh) Exists(sub Builder) Where {
+	w.items = append(w.items, NewExists(sub))
+
+	return w
+}
+
+// NotExists generates where sql like AND NOT EXISTS (SELECT ...)
+func (w *Wh) NotExists(sub Builder) Where {
+	w.items = append(w.items, NewNotExists(sub))
+
+	return w
+}
+
+// WhereSub generates where sql like AND `field` = (SELECT ...)
+func (w *Wh) WhereSub(field, relation string, sub Builder) Where {
+	w.AppendItem(NewWhereSub(field, relation, sub))
+
+	return w
+}
+
+// Like generates where sql like AND `field` LIKE ?
+func (w *Wh) Like(field string, pattern interface{}) Where {
+	w.items = append(w.items, NewLike(typeLike, field, pattern, false))
+
+	return w
+}
+
+// NotLike generates where sql like AND `field` NOT LIKE ?
+func (w *Wh) NotLike(field string, pattern interface{}) Where {
+	w.items = append(w.items, NewLike(typeNotLike, field, pattern, false))
+
+	return w
+}
+
+// OrLike generates where sql like OR `field` LIKE ?
+func (w *Wh) OrLike(field string, pattern interface{}) Where {
+	w.items = append(w.items, NewLike(typeOrLike, field, pattern, false))
+
+	return w
+}
+
+// ILike generates where sql like AND `field` ILIKE ?, or AND LOWER(`field`) LIKE LOWER(?) where ILIKE is not supported
+func (w *Wh) ILike(field string, pattern interface{}) Where {
+	w.items = append(w.items, NewLike(typeILike, field, pattern, false))
+
+	return w
+}
+
+// Contains generates where sql like AND `field` LIKE ? ESCAPE '\' matching the value anywhere
+func (w *Wh) Contains(field, value string) Where {
+	w.items = append(w.items, NewLike(typeLike, field, likePattern{"%", value, "%"}, true))
+
+	return w
+}
+
+// StartsWith generates where sql like AND `field` LIKE ? ESCAPE '\' matching the value at the beginning
+func (w *Wh) StartsWith(field, value string) Where {
+	w.items = append(w.items, NewLike(typeLike, field, likePattern{"", value, "%"}, true))
+
+	return w
+}
+
+// EndsWith generates where sql like AND `field` LIKE ? ESCAPE '\' matching the value at the end
+func (w *Wh) EndsWith(field, value string) Where {
+	w.items = append(w.items, NewLike(typeLike, field, likePattern{"%", value, ""}, true))
+
+	return w
+}
+
 // GetItems returns the child items of where
 func (w *Wh) GetItems() []Where {
 	return w.items
@@ -370,7 +590,7 @@ func (w *Wh) GetField() string {
 
 // GetRelation returns the relational operator between WHERE ? = ? like =, <, >, <=, >= ....
 func (w *Wh) GetRelation() string {
-	return w.relation
+	return string(w.relation)
 }
 
 // GetValue returns the value for the binding params set by WHERE clause
@@ -388,11 +608,40 @@ func (w *Wh) GetInValues() []interface{} {
 	return w.inValues
 }
 
-// AppendItem add a new WHERE builder object to the multiple and recursive WHERE blocks
+// GetSub returns the subquery of IN, EXISTS or comparison clauses
+func (w *Wh) GetSub() Builder {
+	return w.sub
+}
+
+// AppendItem add a new WHERE builder object to the multiple and recursive WHERE blocks.
+// In strict mode it panics if the new item has a construction error
 func (w *Wh) AppendItem(wh Where) {
+	if child, ok := wh.(*Wh); ok {
+		child.strict = w.strict
+	}
+
+	if w.strict && wh.GetError() != nil {
+		panic(wh.GetError())
+	}
+
 	w.items = append(w.items, wh)
 }
 
+// GetColumn returns the right hand side column of a column comparison
+func (w *Wh) GetColumn() string {
+	return w.column
+}
+
+// GetError returns the error occurred while constructing this WHERE item, like an invalid relation
+func (w *Wh) GetError() error {
+	return w.err
+}
+
+// GetIsEscaped returns if the LIKE pattern was escaped and needs an ESCAPE clause
+func (w *Wh) GetIsEscaped() bool {
+	return w.escaped
+}
+
 // GetIsRaw returns if the condition field  needs to be quoted
 func (w *Wh) GetIsRaw() bool {
 	return w.raw
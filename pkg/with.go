@@ -0,0 +1,130 @@
+package builder
+
+import (
+	"fmt"
+	"strings"
+)
+
+// cte is a common table expression of the WITH clause
+type cte struct {
+	name         string
+	columns      []string
+	query        Builder
+	recursive    Builder
+	materialized bool
+}
+
+// With adds a common table expression, like WITH `name` AS (SELECT ...). Call it before Select, Insert, Update or Delete,
+// the expressions are attached to the statement initiated next. Called after them, the expressions are attached to the current
+// statement and AsSQL drops them from the next one
+func (b *Build) With(name string, q Builder) Builder {
+	return b.appendWith(&cte{name: name, query: q})
+}
+
+// WithRecursive adds a recursive common table expression, like WITH RECURSIVE `name` (`col1`,`col2`) AS (<anchor> UNION ALL <recursive>)
+func (b *Build) WithRecursive(name string, columns []string, anchor, recursive Builder) Builder {
+	return b.appendWith(&cte{name: name, columns: columns, query: anchor, recursive: recursive})
+}
+
+// WithMaterialized adds a common table expression which is always materialized, like WITH `name` AS MATERIALIZED (SELECT ...).
+// Supported by PostgreSQL and SQLite
+func (b *Build) WithMaterialized(name string, q Builder) Builder {
+	return b.appendWith(&cte{name: name, query: q, materialized: true})
+}
+
+func (b *Build) appendWith(c *cte) Builder {
+	if !b.ctesPending {
+		b.ctes = nil
+	}
+	b.ctes = append(b.ctes, c)
+	b.ctesPending = true
+	return b
+}
+
+func (b *Build) generateWith() string {
+	if len(b.ctes) == 0 {
+		return ""
+	}
+
+	strBuilder := &strings.Builder{}
+	strBuilder.WriteString("WITH ")
+	if b.dialect.Supports(FeatureRecursiveKeyword) && b.hasRecursiveWith() {
+		strBuilder.WriteString("RECURSIVE ")
+	}
+
+	for i, c := range b.ctes {
+		if i > 0 {
+			strBuilder.WriteString(",")
+		}
+
+		strBuilder.WriteString(b.quoteAlias(c.name))
+		if len(c.columns) > 0 {
+			builderConcat(strBuilder, " (", b.getFieldList(c.columns), ")")
+		}
+
+		strBuilder.WriteString(" AS ")
+		if c.materialized {
+			if !b.dialect.Supports(FeatureMaterializedCTE) {
+				b.setError(fmt.Errorf("%w: WITH ... AS MATERIALIZED", ErrNotSupported))
+			}
+			strBuilder.WriteString("MATERIALIZED ")
+		}
+
+		builderConcat(strBuilder, "(", b.generateSubSQL(c.query))
+		if c.recursive != nil {
+			builderConcat(strBuilder, " UNION ALL ", b.generateSubSQL(c.recursive))
+		}
+		strBuilder.WriteString(")")
+	}
+	strBuilder.WriteString(" ")
+
+	return strBuilder.String()
+}
+
+// leadsWith reports if the dialect accepts the common table expressions in front of the statement
+func (b *Build) leadsWith() bool {
+	switch b.sQLType {
+	case typeInsert:
+		return b.dialect.Supports(FeatureWithInsert)
+	case typeUpdate, typeDelete:
+		return b.dialect.Supports(FeatureWithUpdateDelete)
+	default:
+		return true
+	}
+}
+
+// embedsWith reports if the common table expressions are rendered in front of the SELECT of INSERT ... SELECT,
+// like INSERT INTO `t` WITH ... SELECT, for dialects without leading WITH on INSERT
+func (b *Build) embedsWith() bool {
+	if b.sQLType != typeInsert || b.insertFrom == nil || b.dialect.Supports(FeatureWithInsert) {
+		return false
+	}
+
+	style := b.dialect.UpsertStyle()
+	return b.upsert == nil || style == UpsertOnConflict || style == UpsertOnDuplicateKey
+}
+
+func (b *Build) hasRecursiveWith() bool {
+	for _, c := range b.ctes {
+		if c.recursive != nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (b *Build) getWithParams() []interface{} {
+	var pars []interface{}
+	for _, c := range b.ctes {
+		if c.query != nil {
+			pars = append(pars, b.getSubParams(c.query)...)
+		}
+
+		if c.recursive != nil {
+			pars = append(pars, b.getSubParams(c.recursive)...)
+		}
+	}
+
+	return pars
+}